@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+
+	commonModels "github.com/kattana-io/models/pkg/storage"
+	"github.com/kattana-io/tron-blocks-parser/internal/abi"
+	"github.com/kattana-io/tron-blocks-parser/internal/cache"
+	"github.com/kattana-io/tron-blocks-parser/internal/config"
+	"github.com/kattana-io/tron-blocks-parser/internal/converters"
+	"github.com/kattana-io/tron-blocks-parser/internal/helper"
+	"github.com/kattana-io/tron-blocks-parser/internal/integrations"
+	"github.com/kattana-io/tron-blocks-parser/internal/models"
+	"github.com/kattana-io/tron-blocks-parser/internal/node"
+	"github.com/kattana-io/tron-blocks-parser/internal/parser"
+	"github.com/kattana-io/tron-blocks-parser/internal/runway"
+	"github.com/kattana-io/tron-blocks-parser/internal/tracing"
+	"go.uber.org/zap"
+)
+
+// app - dependencies shared by commands
+type app struct {
+	cfg             *config.Config
+	runner          *runway.Runway
+	logger          *zap.Logger
+	shutdownTracing func(context.Context) error
+	abiHolder       *abi.Holder
+	quotesFile      *helper.QuotesFile
+	tokenLists      *integrations.TokenListsProvider
+	sunswapLists    *integrations.SunswapProvider
+	pairsCache      cache.PairCache
+	nodes           *node.Pool
+}
+
+// newApp - load configuration with given loader and create dependencies, exits on invalid configuration
+func newApp(ctx context.Context, load func() (*config.Config, error)) *app {
+	cfg, cfgErr := load()
+	runner := runway.Create(cfg.Redis, cfg.HTTP, cfg.LogLevel)
+	logger := runner.Logger()
+	if cfgErr != nil {
+		logger.Fatal("Invalid configuration", zap.Error(cfgErr))
+	}
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing, version)
+	if err != nil {
+		logger.Fatal("Could not set up tracing", zap.Error(err))
+	}
+	if len(cfg.Node.TrongridKeys) > 0 {
+		http.DefaultTransport = node.NewKeyTransport(http.DefaultTransport, cfg.Node.TrongridKeys)
+	}
+	return &app{
+		cfg:             cfg,
+		runner:          runner,
+		logger:          logger,
+		shutdownTracing: shutdownTracing,
+		abiHolder:       abi.Create(),
+		quotesFile:      helper.NewQuotesFile(),
+		tokenLists:      integrations.NewTokensListProvider(),
+		sunswapLists:    integrations.NewSunswapProvider(),
+		pairsCache:      cache.NewPairsCache(runner.Redis()),
+		nodes:           node.New(cfg.Node, logger),
+	}
+}
+
+// loadParserConfig - commands which parse blocks without kafka
+func loadParserConfig() (*config.Config, error) {
+	cfg, err := config.Read()
+	if err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.ValidateParser()
+}
+
+// newParser - parser of single block, mode defines how converter restores and stores prices
+func (a *app) newParser(ctx context.Context, block *commonModels.Block, log *zap.Logger, mode models.Mode) *parser.Parser {
+	a.nodes.Add(block.Node)
+	fiatConverter := converters.CreateConverter(ctx, a.runner.Redis(), log, block, a.quotesFile.Get(), mode)
+	return parser.New(a.nodes, a.tokenLists, a.pairsCache, fiatConverter, a.abiHolder, a.sunswapLists, a.cfg.Node.FullNodeURL, log)
+}
+
+// block - block of TRON network requested by number, it is downloaded from configured node
+func (a *app) block(number uint64) commonModels.Block {
+	return commonModels.Block{
+		Network: parser.Chain,
+		Number:  new(big.Int).SetUint64(number),
+		Node:    a.cfg.Node.FullNodeURL,
+	}
+}
+
+// parseBlock - parse block requested by number with logger scoped to it, nothing is written to Redis
+func (a *app) parseBlock(ctx context.Context, number uint64, mode models.Mode) (p *parser.Parser, err error) {
+	block := a.block(number)
+	ctx, span := tracing.Start(ctx, "parseBlock",
+		tracing.BlockNumber.Int64(block.Number.Int64()),
+		tracing.BlockNetwork.String(block.Network))
+	defer func() { tracing.End(span, err) }()
+	log := a.blockLogger(ctx, &block)
+
+	p = a.newParser(ctx, &block, log, mode)
+	if !p.ParseDryRun(ctx, block) {
+		return nil, p.Err()
+	}
+	log.Info("Block parsed", zap.Object("summary", p.Summary()))
+	return p, nil
+}
+
+// blockLogger - every log line of the block carries its correlation id
+func (a *app) blockLogger(ctx context.Context, block *commonModels.Block) *zap.Logger {
+	return a.logger.With(
+		zap.String("correlation_id", tracing.CorrelationID(ctx)),
+		zap.String("network", block.Network),
+		zap.Uint64("block", block.Number.Uint64()))
+}
+
+// close - flush spans, close redis and stop http server if it was started
+func (a *app) close() {
+	ctx, cancel := context.WithTimeout(context.Background(), abortTimeout)
+	defer cancel()
+	if err := a.shutdownTracing(ctx); err != nil {
+		a.logger.Error("Could not flush spans", zap.Error(err))
+	}
+	if err := a.runner.Shutdown(ctx); err != nil {
+		a.logger.Error("Could not stop runway", zap.Error(err))
+	}
+	_ = a.logger.Sync()
+}
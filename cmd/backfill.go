@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/kattana-io/tron-blocks-parser/internal/backfill"
+	"github.com/kattana-io/tron-blocks-parser/internal/config"
+	"github.com/kattana-io/tron-blocks-parser/internal/models"
+	"github.com/kattana-io/tron-blocks-parser/internal/parser"
+	"github.com/kattana-io/tron-blocks-parser/internal/transport"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func newBackfillCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backfill",
+		Short: "Enqueue range of blocks into prices or history topic, interrupted backfill of the same range is resumed",
+		Args:  cobra.NoArgs,
+		RunE:  runBackfill,
+	}
+	cmd.Flags().String("topic", "history", "prices to build price checkpoints of range first, then history to parse it")
+	cmd.Flags().Uint64("from", 0, "First block of range")
+	cmd.Flags().Uint64("to", 0, "Last block of range, inclusive")
+	cmd.Flags().Float64("rate", 50, "Blocks per second, 0 is unlimited")
+	cmd.Flags().Int("batch", 100, "Blocks written in one batch, reduced to rate")
+	cmd.Flags().Bool("restart", false, "Ignore saved progress and enqueue range from the start")
+	cmd.Flags().String("node", "", "Node of enqueued blocks, defaults to node.full_node_url")
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("to")
+	return cmd
+}
+
+func runBackfill(cmd *cobra.Command, _ []string) error {
+	from, _ := cmd.Flags().GetUint64("from")
+	to, _ := cmd.Flags().GetUint64("to")
+	rate, _ := cmd.Flags().GetFloat64("rate")
+	batch, _ := cmd.Flags().GetInt("batch")
+	restart, _ := cmd.Flags().GetBool("restart")
+	node, _ := cmd.Flags().GetString("node")
+	target, _ := cmd.Flags().GetString("topic")
+	var phase models.Mode
+	switch target {
+	case "prices":
+		phase = models.PRICES
+	case "history":
+		phase = models.HISTORY
+	default:
+		return fmt.Errorf("unknown topic %q, expected prices or history", target)
+	}
+	if from == 0 || to < from {
+		return fmt.Errorf("invalid range %d..%d", from, to)
+	}
+	if rate < 0 || batch < 1 {
+		return fmt.Errorf("invalid rate %v or batch %d", rate, batch)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	a := newApp(ctx, config.Load)
+	defer a.close()
+	if node == "" {
+		node = a.cfg.Node.FullNodeURL
+	}
+
+	kafkaCfg := a.cfg.Kafka
+	topic := a.cfg.ModeTopic(phase)
+	producer := transport.NewBlockProducer(topic, kafkaCfg.Brokers, kafkaCfg.KeyStrategy, kafkaCfg.Producer, a.logger)
+	defer func() {
+		if err := producer.Close(); err != nil {
+			a.logger.Error("Could not close producer", zap.Error(err))
+		}
+	}()
+
+	log := a.logger.With(zap.String("topic", topic), zap.Uint64("from", from), zap.Uint64("to", to))
+	log.Info("Start backfill", zap.Float64("rate", rate), zap.Int("batch", batch))
+	start := time.Now()
+	b := backfill.New(producer, backfill.NewRedisStore(a.runner.Redis()), parser.Chain, node, phase, rate, batch, log)
+	progress, err := b.Run(ctx, from, to, restart)
+	if err != nil {
+		if progress != nil {
+			log.Error("Backfill stopped, run the same range again to resume",
+				zap.Uint64("next", progress.Next),
+				zap.Uint64("enqueued", progress.Enqueued()),
+				zap.Error(err))
+		}
+		return err
+	}
+	log.Info("Backfill finished",
+		zap.Uint64("enqueued", progress.Enqueued()),
+		zap.Time("finished_at", progress.FinishedAt),
+		zap.Duration("duration", time.Since(start)))
+	return nil
+}
@@ -0,0 +1,442 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/goccy/go-json"
+	commonModels "github.com/kattana-io/models/pkg/storage"
+	"github.com/kattana-io/tron-blocks-parser/internal/config"
+	"github.com/kattana-io/tron-blocks-parser/internal/encoding"
+	"github.com/kattana-io/tron-blocks-parser/internal/metrics"
+	"github.com/kattana-io/tron-blocks-parser/internal/models"
+	"github.com/kattana-io/tron-blocks-parser/internal/parser"
+	"github.com/kattana-io/tron-blocks-parser/internal/retry"
+	"github.com/kattana-io/tron-blocks-parser/internal/tracing"
+	"github.com/kattana-io/tron-blocks-parser/internal/transport"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+const (
+	// abortTimeout - grace period for in-flight block after its context was cancelled
+	abortTimeout = 5 * time.Second
+	// pauseTimeout - delay before retrying a block which could not be published
+	pauseTimeout = 30 * time.Second
+	// retry policy of RETRY mode, delay doubles after each failed attempt
+	maxRetryAttempts = 10
+	retryBaseDelay   = 30 * time.Second
+	retryMaxDelay    = time.Hour
+)
+
+func newConsumeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "consume",
+		Short: "Consume blocks of --mode from kafka, parse and publish them (default)",
+		Args:  cobra.NoArgs,
+		Run:   runConsume,
+	}
+}
+
+func runConsume(*cobra.Command, []string) {
+	/**
+	 * appCtx - publishing and committing, cancelled only when in-flight block can't be drained in time
+	 * consumeCtx - fetching and waiting, cancelled on shutdown signal
+	 */
+	appCtx, cancel := context.WithCancel(context.Background())
+	consumeCtx, stopConsuming := context.WithCancel(appCtx)
+	gracefulShutdown := make(chan os.Signal, 1)
+	signal.Notify(gracefulShutdown, syscall.SIGINT, syscall.SIGTERM)
+
+	a := newApp(appCtx, config.Load)
+	cfg, runner, logger := a.cfg, a.runner, a.logger
+	mode := cfg.Mode
+	runner.Serve()
+
+	/**
+	 * Readiness checks, redis is checked by runway. Kafka is checked by reading offsets of consumer group,
+	 * its lag makes idle main loop stale
+	 */
+	health := runner.Health()
+	groupLag := func(ctx context.Context) (int64, error) {
+		return transport.GroupLag(ctx, cfg.Kafka.Brokers, cfg.Kafka.GroupID, cfg.InputTopic())
+	}
+	health.AddCheck("kafka", func(ctx context.Context) error {
+		_, err := groupLag(ctx)
+		return err
+	})
+	health.SetBacklog(groupLag)
+	health.AddCheck("node", func(ctx context.Context) error {
+		return pingNode(ctx, cfg.Node.FullNodeURL)
+	})
+	health.AddCheck("files", func(context.Context) error {
+		switch {
+		case !a.quotesFile.Loaded():
+			return errors.New("quotes.json is not loaded")
+		case !a.tokenLists.Loaded():
+			return errors.New("tokens.json is not loaded")
+		case !a.sunswapLists.Loaded():
+			return errors.New("sunswap.json is not loaded")
+		}
+		return nil
+	})
+
+	logger.Info(fmt.Sprintf("Start parser in %s mode", mode))
+
+	kafkaCfg := cfg.Kafka
+	brokerAddr := kafkaCfg.Brokers
+	// Checkpoints carry prices from the previous block, so they are built strictly in order
+	if mode == models.PRICES {
+		partitions, err := transport.Partitions(appCtx, brokerAddr, cfg.InputTopic())
+		if err != nil {
+			logger.Fatal("Could not read partitions of prices topic", zap.Error(err))
+		}
+		if partitions != 1 {
+			logger.Fatal("Prices topic should have a single partition", zap.String("topic", cfg.InputTopic()), zap.Int("partitions", partitions))
+		}
+	}
+	keyStrategy := kafkaCfg.KeyStrategy
+	encoder, err := encoding.New(kafkaCfg.Encoding)
+	if err != nil {
+		logger.Fatal("Invalid encoding", zap.Error(err))
+	}
+	/**
+	 * With transactions outputs of block, failed and dead blocks and consumer offset are committed atomically
+	 */
+	var consumer transport.Source
+	var createPublisher func(topic string) transport.BlockPublisher
+	if kafkaCfg.Transactions.Enabled {
+		session, txErr := transport.NewTxSession(cfg.InputTopic(), kafkaCfg.GroupID, brokerAddr,
+			kafkaCfg.Transactions, kafkaCfg.Reader, kafkaCfg.Producer, keyStrategy, mode, logger)
+		if txErr != nil {
+			logger.Fatal("Could not start transactional session", zap.Error(txErr))
+		}
+		consumer = session
+		createPublisher = func(topic string) transport.BlockPublisher {
+			return session.Publisher(topic)
+		}
+	} else {
+		consumer = transport.NewConsumer(cfg.InputTopic(), kafkaCfg.GroupID, brokerAddr, kafkaCfg.Reader, logger)
+		createPublisher = func(topic string) transport.BlockPublisher {
+			return transport.NewPublisher(topic, brokerAddr, keyStrategy, mode, kafkaCfg.Producer, logger)
+		}
+	}
+	out := newOutputs(&kafkaCfg, encoder, createPublisher)
+	failedPublisher := transport.NewFailedPublisher(createPublisher(kafkaCfg.Topic(kafkaCfg.Topics.Failed)), version)
+	deadPublisher := createPublisher(kafkaCfg.Topic(kafkaCfg.Topics.Dead))
+
+	/**
+	 * Process block and publish results, attempt - number of current attempt to parse the block,
+	 * blockMode - PRICES when block is a price checkpoint, retried checkpoints are built by RETRY worker
+	 */
+	processBlock := func(block commonModels.Block, attempt int, blockMode models.Mode) (err error) {
+		/**
+		 * Check for valid block number
+		 * Why we can have 0 here? Invalid value in JSON
+		 */
+		if block.Number == nil || block.Number.Int64() == 0 {
+			logger.Info("Received null block number, skipping")
+			return nil
+		}
+		/**
+		 * Process block, every log line of the block carries its correlation id
+		 */
+		ctx, span := tracing.Start(appCtx, "processBlock",
+			tracing.BlockNumber.Int64(block.Number.Int64()),
+			tracing.BlockNetwork.String(block.Network),
+			tracing.Mode.String(string(mode)),
+			tracing.Attempt.Int(attempt))
+		defer func() { tracing.End(span, err) }()
+		log := a.blockLogger(ctx, &block).With(
+			zap.String("mode", string(mode)),
+			zap.Int("attempt", attempt))
+
+		var summary parser.Summary
+		outcome := "published"
+		defer func(start time.Time) {
+			log.Info("Block processed",
+				zap.String("outcome", outcome),
+				zap.Object("summary", summary),
+				zap.Duration("duration", time.Since(start)),
+				zap.Error(err))
+		}(time.Now())
+
+		// fail - return block to failed blocks, error only when it could not be returned
+		fail := func(stage, reason string) error {
+			outcome = stage + "_failed"
+			metrics.BlocksFailed.WithLabelValues(string(mode), stage).Inc()
+			if err := failedPublisher.PublishFailedBlock(ctx, block, blockMode, reason, attempt); err != nil {
+				log.Error("Could not return block to failed blocks", zap.Error(err))
+				return err
+			}
+			return nil
+		}
+
+		p := a.newParser(ctx, &block, log, blockMode)
+		if blockMode == models.PRICES {
+			ok := p.ParsePrices(ctx, block)
+			summary = p.Summary()
+			if !ok {
+				log.Error("Could not build price checkpoint, returning it to failed blocks", zap.Error(p.Err()))
+				return fail("parse", fmt.Sprintf("could not build price checkpoint: %v", p.Err()))
+			}
+			outcome = "checkpoint"
+			metrics.BlocksParsed.WithLabelValues(string(mode)).Inc()
+			metrics.ObserveBlock(block.Network, string(mode), block.Number.Uint64(), block.Timestamp)
+			return nil
+		}
+		if !p.Parse(ctx, block) {
+			summary = p.Summary()
+			return fail("parse", fmt.Sprintf("could not parse block: %v", p.Err()))
+		}
+		summary = p.Summary()
+		if err = out.publish(ctx, &block, p); err != nil {
+			log.Error("Could not publish block, returning it to failed blocks", zap.Error(err))
+			return fail("publish", fmt.Sprintf("could not publish block: %v", err))
+		}
+		metrics.BlocksParsed.WithLabelValues(string(mode)).Inc()
+		metrics.ObserveBlock(block.Network, string(mode), block.Number.Uint64(), block.Timestamp)
+		return nil
+	}
+
+	/**
+	 * Retry block from failed_blocks after backoff, move it to dead blocks after maxRetryAttempts.
+	 * Blocks whose backoff isn't over are requeued, so they don't hold blocks behind them
+	 */
+	schedule := retry.NewSchedule(retryBaseDelay)
+	retryBlock := func(msg []byte) error {
+		failed := models.FailedBlock{}
+		if err := json.Unmarshal(msg, &failed); err != nil {
+			logger.Error(err.Error())
+			return nil
+		}
+		// Blocks published before envelope was introduced
+		if failed.Block.Number == nil {
+			if err := json.Unmarshal(msg, &failed.Block); err != nil {
+				logger.Error(err.Error())
+				return nil
+			}
+		}
+
+		meta := transport.Meta{
+			Network:  failed.Block.Network,
+			Number:   failed.Block.Number.Uint64(),
+			Schema:   models.FailedBlockSchemaVersion,
+			Encoding: models.JSON,
+		}
+		if failed.Attempt >= maxRetryAttempts {
+			logger.Warn("Moving block to dead blocks",
+				zap.String("block", failed.Block.Number.String()),
+				zap.Int("attempt", failed.Attempt),
+				zap.String("reason", failed.Reason))
+			return deadPublisher.PublishBlock(appCtx, meta, msg)
+		}
+
+		key := fmt.Sprintf("%s:%s:%d:%d", failed.Block.Network, failed.Block.Number, failed.Attempt, failed.Timestamp)
+		wait, due := schedule.Next(key, failed.RetryAt(retryBaseDelay, retryMaxDelay), time.Now())
+		if wait > 0 {
+			select {
+			case <-consumeCtx.Done():
+				return consumeCtx.Err()
+			case <-time.After(wait):
+			}
+		}
+		if !due {
+			// Envelope is unchanged, so backoff is still counted from the failure
+			return failedPublisher.PublishBlock(appCtx, meta, msg)
+		}
+		blockMode := mode
+		if failed.Mode == models.PRICES {
+			blockMode = models.PRICES
+		}
+		return processBlock(failed.Block, failed.Attempt+1, blockMode)
+	}
+
+	/**
+	 * Decode message and process it according to mode
+	 */
+	processMessage := func(msg []byte) error {
+		if mode == models.RETRY {
+			return retryBlock(msg)
+		}
+
+		block := commonModels.Block{}
+		if err := json.Unmarshal(msg, &block); err != nil {
+			logger.Error(err.Error())
+			return nil
+		}
+		return processBlock(block, 1, mode)
+	}
+
+	/**
+	 * Parse block on demand for support, nothing is published unless requested.
+	 * Transactional publishers belong to the main loop, so publishing uses its own writers
+	 */
+	runner.HandleParse(func(ctx context.Context, number uint64, publish bool) ([]byte, error) {
+		// Block is behind the head like retried ones, prices are restored from checkpoints and not stored
+		p, err := a.parseBlock(ctx, number, models.RETRY)
+		if err != nil {
+			return nil, err
+		}
+		body, err := json.Marshal(p.State())
+		if err != nil || !publish {
+			return body, err
+		}
+		admin := newOutputs(&kafkaCfg, encoder, func(topic string) transport.BlockPublisher {
+			return transport.NewPublisher(topic, brokerAddr, keyStrategy, mode, kafkaCfg.Producer, logger)
+		})
+		err = admin.publish(ctx, p.State().Block, p)
+		return body, errors.Join(err, admin.close())
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for consumeCtx.Err() == nil {
+			msg, err := consumer.Fetch(consumeCtx)
+			if err != nil {
+				if consumeCtx.Err() != nil {
+					zap.L().Info("gracefully closing app")
+					return
+				}
+				zap.L().Error("Exiting because can't read from queue", zap.Error(err))
+				select {
+				case gracefulShutdown <- os.Interrupt:
+				default: // shutdown was already requested
+				}
+				return
+			}
+
+			// Fetch blocks as long as topic is idle, so waiting loop is stale only when group has lag
+			health.Busy()
+			// Block could be neither published nor returned, roll it back and pause consumption without committing offset
+			if err := processMessage(msg.Value); err != nil {
+				if err := consumer.Rollback(appCtx, msg); err != nil {
+					zap.L().Error("Could not roll back block", zap.Error(err), zap.Int64("offset", msg.Offset))
+				}
+				health.Idle()
+				zap.L().Warn("Pausing consumption", zap.Duration("pause", pauseTimeout))
+				select {
+				case <-consumeCtx.Done():
+					return
+				case <-time.After(pauseTimeout):
+				}
+				continue
+			}
+
+			// Offset is committed only after block was published, so crash in between leads to redelivery
+			if err := consumer.Commit(appCtx, msg); err != nil {
+				zap.L().Error("Could not commit offset", zap.Error(err), zap.Int64("offset", msg.Offset))
+			}
+			health.Idle()
+		}
+	}()
+
+	<-gracefulShutdown
+	stopConsuming()
+	drain(done, cancel, cfg.ShutdownTimeout)
+
+	publishers := append(out.publishers(), failedPublisher, deadPublisher)
+	handleTermination(a, consumer, publishers...)
+}
+
+// outputs - publishers of parsed block, its entities and holders
+type outputs struct {
+	parsed  transport.BlockPublisher
+	holders transport.BlockPublisher
+	fanOut  *transport.FanOutPublisher
+	encoder encoding.Encoder
+	batch   bool
+}
+
+func newOutputs(kafkaCfg *config.Kafka, encoder encoding.Encoder, create func(topic string) transport.BlockPublisher) *outputs {
+	out := &outputs{
+		parsed:  create(kafkaCfg.Topic(kafkaCfg.Topics.Parsed)),
+		holders: create(kafkaCfg.Topic(kafkaCfg.Topics.Holders)),
+		encoder: encoder,
+		batch:   kafkaCfg.FanOut.Batch,
+	}
+	if kafkaCfg.FanOut.Enabled {
+		out.fanOut = transport.NewFanOutPublisher(kafkaCfg.EntityTopics(), create)
+	}
+	return out
+}
+
+// publish - publish parsed block, holders are deleted from state after encoding
+func (o *outputs) publish(ctx context.Context, block *commonModels.Block, p *parser.Parser) error {
+	encodedHolders := p.GetEncodedHolders(o.encoder)
+	p.DeleteHolders()
+	meta := transport.Meta{
+		Network:  block.Network,
+		Number:   block.Number.Uint64(),
+		Schema:   parser.SchemaVersion,
+		Encoding: o.encoder.Name(),
+	}
+	if err := o.parsed.PublishBlock(ctx, meta, p.GetEncodedBlock(o.encoder)); err != nil {
+		return err
+	}
+	if o.fanOut != nil {
+		entities, err := p.GetEncodedEntities(o.encoder, o.batch)
+		if err != nil {
+			return err
+		}
+		if err := o.fanOut.Publish(ctx, meta, entities); err != nil {
+			return err
+		}
+	}
+	return o.holders.PublishBlock(ctx, meta, encodedHolders)
+}
+
+func (o *outputs) publishers() []transport.BlockPublisher {
+	publishers := []transport.BlockPublisher{o.parsed, o.holders}
+	if o.fanOut != nil {
+		publishers = append(publishers, o.fanOut.Publishers()...)
+	}
+	return publishers
+}
+
+// close - flush and close all publishers
+func (o *outputs) close() error {
+	var errs []error
+	for _, publisher := range o.publishers() {
+		errs = append(errs, publisher.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// drain - wait until in-flight block is published and committed, abort it after timeout
+func drain(done <-chan struct{}, cancel context.CancelFunc, timeout time.Duration) {
+	defer cancel()
+	zap.L().Info("Stop consuming, waiting for in-flight block", zap.Duration("timeout", timeout))
+	select {
+	case <-done:
+		return
+	case <-time.After(timeout):
+	}
+
+	zap.L().Warn("In-flight block was not finished in time, aborting it")
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(abortTimeout):
+		zap.L().Error("In-flight block was not aborted, it will be consumed again")
+	}
+}
+
+// handleTermination - close consumer after last commit, flush writers, then spans, http server and redis
+func handleTermination(a *app, consumer transport.Source, publishers ...transport.BlockPublisher) {
+	zap.L().Info("Start terminating process")
+	consumer.Close()
+	for _, publisher := range publishers {
+		if err := publisher.Close(); err != nil {
+			zap.L().Error("Could not close publisher", zap.Error(err))
+		}
+	}
+	zap.L().Info("Finish")
+	a.close()
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"os/signal"
+	"syscall"
+
+	"github.com/goccy/go-json"
+	"github.com/kattana-io/tron-blocks-parser/internal/models"
+	"github.com/spf13/cobra"
+)
+
+func newInspectTxCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inspect-tx <hash>",
+		Short: "Show every log of transaction, handler which decoded it and emitted entities",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runInspectTx,
+	}
+	cmd.Flags().Uint64("block", 0, "Block of transaction, looked up on node when omitted")
+	return cmd
+}
+
+func runInspectTx(cmd *cobra.Command, args []string) error {
+	hash := args[0]
+	number, _ := cmd.Flags().GetUint64("block")
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	a := newApp(ctx, loadParserConfig)
+	defer a.close()
+
+	if number == 0 {
+		var err error
+		if number, err = lookupTransactionBlock(ctx, a.cfg.Node.FullNodeURL, hash); err != nil {
+			return err
+		}
+	}
+	block := a.block(number)
+	p := a.newParser(ctx, &block, a.blockLogger(ctx, &block), models.HISTORY)
+	report, err := p.InspectTransaction(ctx, block, hash)
+	if err != nil {
+		return err
+	}
+	out, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = cmd.OutOrStdout().Write(append(out, '\n'))
+	return err
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/goccy/go-json"
+	"github.com/kattana-io/tron-blocks-parser/internal/node"
+)
+
+// pingNode - check that node answers, trongrid is used when nodeURL is empty
+func pingNode(ctx context.Context, nodeURL string) error {
+	resp, err := nodeRequest(ctx, nodeURL, "/wallet/getnowblock", nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// lookupTransactionBlock - number of block which contains transaction
+func lookupTransactionBlock(ctx context.Context, nodeURL, hash string) (uint64, error) {
+	resp, err := nodeRequest(ctx, nodeURL, "/wallet/gettransactioninfobyid", map[string]string{"value": hash})
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	info := struct {
+		BlockNumber uint64 `json:"blockNumber"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return 0, err
+	}
+	if info.BlockNumber == 0 {
+		return 0, fmt.Errorf("transaction %s not found", hash)
+	}
+	return info.BlockNumber, nil
+}
+
+// nodeRequest - call http api of node, trongrid is used when nodeURL is empty
+func nodeRequest(ctx context.Context, nodeURL, path string, payload any) (*http.Response, error) {
+	if nodeURL == "" {
+		nodeURL = node.TrongridURL
+	}
+	var body io.Reader = http.NoBody
+	if payload != nil {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, nodeURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("node responded with %s", resp.Status)
+	}
+	return resp, nil
+}
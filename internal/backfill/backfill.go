@@ -0,0 +1,139 @@
+package backfill
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	commonModels "github.com/kattana-io/models/pkg/storage"
+	"github.com/kattana-io/tron-blocks-parser/internal/models"
+	"go.uber.org/zap"
+)
+
+/**
+ * Producer of block ranges for PRICES or HISTORY workers, progress is saved after every batch
+ * so interrupted backfill continues from the first block which was not enqueued
+ */
+
+// Producer - writes batch of blocks to input topic
+type Producer interface {
+	Produce(ctx context.Context, blocks []commonModels.Block) error
+}
+
+type Backfill struct {
+	producer Producer
+	store    Store
+	network  string
+	node     string
+	// phase - mode of workers consuming enqueued blocks, PRICES pass goes before HISTORY one
+	phase models.Mode
+	batch uint64
+	// interval - delay between batches to keep the rate, 0 is unlimited
+	interval time.Duration
+	log      *zap.Logger
+}
+
+// New - rate in blocks per second, batch is reduced to rate to write at least once a second
+func New(producer Producer,
+	store Store,
+	network string,
+	node string,
+	phase models.Mode,
+	rate float64,
+	batch int,
+	log *zap.Logger) *Backfill {
+	if batch < 1 {
+		batch = 1
+	}
+	b := &Backfill{
+		producer: producer,
+		store:    store,
+		network:  network,
+		node:     node,
+		phase:    phase,
+		batch:    uint64(batch),
+		log:      log,
+	}
+	if rate > 0 {
+		if float64(b.batch) > rate {
+			b.batch = uint64(rate)
+			if b.batch < 1 {
+				b.batch = 1
+			}
+		}
+		b.interval = time.Duration(float64(b.batch) / rate * float64(time.Second))
+	}
+	return b
+}
+
+// Run - enqueue blocks of range [from, to], saved progress of the same range is resumed unless restart
+func (b *Backfill) Run(ctx context.Context, from, to uint64, restart bool) (*Progress, error) {
+	key := progressKey(b.network, b.phase, from, to)
+	progress, err := b.store.Load(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case progress == nil || restart:
+		progress = &Progress{From: from, To: to, Next: from, StartedAt: time.Now()}
+	case progress.Finished():
+		return progress, nil
+	default:
+		b.log.Info("Resuming backfill", zap.Uint64("next", progress.Next), zap.Uint64("enqueued", progress.Enqueued()))
+	}
+
+	var tick <-chan time.Time
+	if b.interval > 0 {
+		ticker := time.NewTicker(b.interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for progress.Next <= to {
+		size := b.batch
+		if left := to - progress.Next + 1; left < size {
+			size = left
+		}
+		if err := b.producer.Produce(ctx, b.blocks(progress.Next, size)); err != nil {
+			return progress, err
+		}
+
+		progress.Next += size
+		progress.UpdatedAt = time.Now()
+		if progress.Next > to {
+			progress.FinishedAt = progress.UpdatedAt
+		}
+		if err := b.store.Save(ctx, key, progress); err != nil {
+			return progress, err
+		}
+		b.log.Debug("Enqueued blocks", zap.Uint64("next", progress.Next), zap.Uint64("enqueued", progress.Enqueued()))
+
+		if progress.Finished() {
+			break
+		}
+		if tick == nil {
+			if err := ctx.Err(); err != nil {
+				return progress, err
+			}
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return progress, ctx.Err()
+		case <-tick:
+		}
+	}
+	return progress, nil
+}
+
+// blocks - history blocks are not notified, timestamp is taken by parser from node
+func (b *Backfill) blocks(from, size uint64) []commonModels.Block {
+	blocks := make([]commonModels.Block, 0, size)
+	for number := from; number < from+size; number++ {
+		blocks = append(blocks, commonModels.Block{
+			Network: b.network,
+			Number:  new(big.Int).SetUint64(number),
+			Node:    b.node,
+		})
+	}
+	return blocks
+}
@@ -0,0 +1,157 @@
+package backfill
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	commonModels "github.com/kattana-io/models/pkg/storage"
+	"github.com/kattana-io/tron-blocks-parser/internal/models"
+	"go.uber.org/zap"
+)
+
+type fakeProducer struct {
+	blocks []uint64
+	// failAt - block number which can't be written
+	failAt uint64
+}
+
+func (f *fakeProducer) Produce(_ context.Context, blocks []commonModels.Block) error {
+	for _, block := range blocks {
+		if block.Number.Uint64() == f.failAt {
+			return errors.New("broker is not available")
+		}
+	}
+	for _, block := range blocks {
+		f.blocks = append(f.blocks, block.Number.Uint64())
+	}
+	return nil
+}
+
+type memoryStore map[string]Progress
+
+func (m memoryStore) Load(_ context.Context, key string) (*Progress, error) {
+	if progress, ok := m[key]; ok {
+		return &progress, nil
+	}
+	return nil, nil
+}
+
+func (m memoryStore) Save(_ context.Context, key string, progress *Progress) error {
+	m[key] = *progress
+	return nil
+}
+
+func TestBackfill_Run(t *testing.T) {
+	const from, to = 100, 109
+	key := progressKey("TRON", models.HISTORY, from, to)
+	tests := []struct {
+		name     string
+		saved    *Progress
+		restart  bool
+		failAt   uint64
+		want     []uint64
+		wantNext uint64
+		wantErr  bool
+	}{
+		{
+			name:     "Full range in batches",
+			want:     []uint64{100, 101, 102, 103, 104, 105, 106, 107, 108, 109},
+			wantNext: 110,
+		},
+		{
+			name:     "Resume from saved progress",
+			saved:    &Progress{From: from, To: to, Next: 107},
+			want:     []uint64{107, 108, 109},
+			wantNext: 110,
+		},
+		{
+			name:     "Restart ignores saved progress",
+			saved:    &Progress{From: from, To: to, Next: 107},
+			restart:  true,
+			want:     []uint64{100, 101, 102, 103, 104, 105, 106, 107, 108, 109},
+			wantNext: 110,
+		},
+		{
+			name:     "Already finished",
+			saved:    &Progress{From: from, To: to, Next: 110, FinishedAt: time.Now()},
+			wantNext: 110,
+		},
+		{
+			name:     "Producer error keeps progress of written batches",
+			failAt:   105,
+			want:     []uint64{100, 101, 102, 103},
+			wantNext: 104,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := memoryStore{}
+			if tt.saved != nil {
+				store[key] = *tt.saved
+			}
+			producer := &fakeProducer{failAt: tt.failAt}
+			b := New(producer, store, "TRON", "", models.HISTORY, 0, 4, zap.NewNop())
+
+			got, err := b.Run(context.Background(), from, to, tt.restart)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.Next != tt.wantNext || store[key].Next != tt.wantNext {
+				t.Errorf("Run() next = %v, saved %v, want %v", got.Next, store[key].Next, tt.wantNext)
+			}
+			if got.Finished() == tt.wantErr {
+				t.Errorf("Run() finished = %v, wantErr %v", got.Finished(), tt.wantErr)
+			}
+			if len(producer.blocks) != len(tt.want) {
+				t.Fatalf("Run() produced %v, want %v", producer.blocks, tt.want)
+			}
+			for i := range tt.want {
+				if producer.blocks[i] != tt.want[i] {
+					t.Errorf("Run() produced %v, want %v", producer.blocks, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestBackfill_RunPhases(t *testing.T) {
+	const from, to = 100, 103
+	store := memoryStore{}
+	store[progressKey("TRON", models.PRICES, from, to)] = Progress{From: from, To: to, Next: to + 1, FinishedAt: time.Now()}
+
+	producer := &fakeProducer{}
+	b := New(producer, store, "TRON", "", models.HISTORY, 0, 4, zap.NewNop())
+	if _, err := b.Run(context.Background(), from, to, false); err != nil {
+		t.Fatal(err)
+	}
+	if len(producer.blocks) != to-from+1 {
+		t.Errorf("history pass produced %v after finished prices pass, want the whole range", producer.blocks)
+	}
+}
+
+func TestNew_rate(t *testing.T) {
+	tests := []struct {
+		name         string
+		rate         float64
+		batch        int
+		wantBatch    uint64
+		wantInterval time.Duration
+	}{
+		{name: "Unlimited", rate: 0, batch: 100, wantBatch: 100, wantInterval: 0},
+		{name: "Batch below rate", rate: 50, batch: 10, wantBatch: 10, wantInterval: 200 * time.Millisecond},
+		{name: "Batch reduced to rate", rate: 20, batch: 100, wantBatch: 20, wantInterval: time.Second},
+		{name: "Rate below one block", rate: 0.5, batch: 100, wantBatch: 1, wantInterval: 2 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(&fakeProducer{}, memoryStore{}, "TRON", "", models.HISTORY, tt.rate, tt.batch, zap.NewNop())
+			if b.batch != tt.wantBatch || b.interval != tt.wantInterval {
+				t.Errorf("New() batch = %v, interval = %v, want %v, %v", b.batch, b.interval, tt.wantBatch, tt.wantInterval)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package backfill
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/goccy/go-json"
+	"github.com/kattana-io/tron-blocks-parser/internal/models"
+)
+
+// Progress - state of backfill of range, saved after every batch
+type Progress struct {
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+	// Next - first block which is not enqueued yet
+	Next       uint64    `json:"next"`
+	StartedAt  time.Time `json:"started_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	FinishedAt time.Time `json:"finished_at,omitempty"`
+}
+
+// Enqueued - number of blocks written to topic
+func (p *Progress) Enqueued() uint64 {
+	return p.Next - p.From
+}
+
+// Finished - every block of range is enqueued
+func (p *Progress) Finished() bool {
+	return !p.FinishedAt.IsZero()
+}
+
+type Store interface {
+	// Load - nil progress when range was never started
+	Load(ctx context.Context, key string) (*Progress, error)
+	Save(ctx context.Context, key string, progress *Progress) error
+}
+
+// progressKey - prices and history passes of the same range are tracked separately
+func progressKey(network string, phase models.Mode, from, to uint64) string {
+	return fmt.Sprintf("parser:backfill:%s:%s:%d-%d", network, phase, from, to)
+}
+
+// RedisStore - progress is kept without ttl, so backfill can be resumed any time
+type RedisStore struct {
+	redis *redis.Client
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{redis: client}
+}
+
+func (s *RedisStore) Load(ctx context.Context, key string) (*Progress, error) {
+	val, err := s.redis.Get(ctx, key).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	progress := &Progress{}
+	if err := json.Unmarshal(val, progress); err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
+
+func (s *RedisStore) Save(ctx context.Context, key string, progress *Progress) error {
+	b, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+	return s.redis.Set(ctx, key, b, 0).Err()
+}
@@ -0,0 +1,449 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kattana-io/tron-blocks-parser/internal/encoding"
+	"github.com/kattana-io/tron-blocks-parser/internal/models"
+	"github.com/spf13/viper"
+	"go.uber.org/zap/zapcore"
+)
+
+/**
+ * Typed configuration, loaded by viper from flags, env and optional YAML file (--config)
+ * Env variables keep their historical names: KAFKA, REDIS_ADDR, REDIS_PASSWORD, FULL_NODE_URL
+ */
+
+type Topics struct {
+	Live    string `mapstructure:"live"`
+	History string `mapstructure:"history"`
+	Prices  string `mapstructure:"prices"`
+	Failed  string `mapstructure:"failed"`
+	Dead    string `mapstructure:"dead"`
+	Parsed  string `mapstructure:"parsed"`
+	Holders string `mapstructure:"holders"`
+}
+
+// EntityTopics - topics of fan-out publisher
+type EntityTopics struct {
+	PairSwaps   string `mapstructure:"pair_swaps"`
+	DirectSwaps string `mapstructure:"direct_swaps"`
+	Liquidities string `mapstructure:"liquidities"`
+	Pairs       string `mapstructure:"pairs"`
+	Transfers   string `mapstructure:"transfers"`
+}
+
+// FanOut - optional publishing of parsed entities into separate topics
+type FanOut struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Batch - one message per entity of block instead of one message per event
+	Batch  bool         `mapstructure:"batch"`
+	Topics EntityTopics `mapstructure:"topics"`
+}
+
+// Transactions - publish outputs and commit consumer offset of block in one Kafka transaction
+type Transactions struct {
+	Enabled bool `mapstructure:"enabled"`
+	// ID - transactional id, unique per running instance, defaults to <group_id>-<hostname>
+	ID      string        `mapstructure:"id"`
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// Producer - settings of writers
+type Producer struct {
+	// MaxMessageBytes - larger payloads are split into chunks, keep it below broker message.max.bytes
+	MaxMessageBytes int                `mapstructure:"max_message_bytes"`
+	Compression     models.Compression `mapstructure:"compression"`
+}
+
+type Reader struct {
+	MinBytes int           `mapstructure:"min_bytes"`
+	MaxBytes int           `mapstructure:"max_bytes"`
+	MaxWait  time.Duration `mapstructure:"max_wait"`
+}
+
+type Kafka struct {
+	Brokers []string `mapstructure:"brokers"`
+	GroupID string   `mapstructure:"group_id"`
+	// TopicPrefix - prepended to every topic, allows to run several environments in one cluster
+	TopicPrefix string   `mapstructure:"topic_prefix"`
+	Topics      Topics   `mapstructure:"topics"`
+	Reader      Reader   `mapstructure:"reader"`
+	Producer    Producer `mapstructure:"producer"`
+	// KeyStrategy - how published messages are keyed: block, network or none
+	KeyStrategy models.KeyStrategy `mapstructure:"key_strategy"`
+	// Encoding - format of parsed blocks and holders: msgpack, json or protobuf
+	Encoding models.Encoding `mapstructure:"encoding"`
+	FanOut   FanOut          `mapstructure:"fan_out"`
+	// Transactions - exactly-once publishing of parsed block, fan-out and holders
+	Transactions Transactions `mapstructure:"transactions"`
+}
+
+type Redis struct {
+	Addr     string `mapstructure:"addr"`
+	Password string `mapstructure:"password"`
+	DB       int    `mapstructure:"db"`
+}
+
+// Endpoint - node of pool
+type Endpoint struct {
+	URL string `mapstructure:"url"`
+	// Kind - full or solidity, solidity nodes never serve constant calls
+	Kind models.NodeKind `mapstructure:"kind"`
+	// RateLimit - requests per second, 0 is unlimited
+	RateLimit float64 `mapstructure:"rate_limit"`
+}
+
+// Retry - retries of failed node calls, every attempt goes through endpoints of pool again
+type Retry struct {
+	// Attempts - including the first one, 1 disables retries
+	Attempts       int           `mapstructure:"attempts"`
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"`
+	// BlockDeadline - time for all node calls of block including retries, 0 disables
+	BlockDeadline time.Duration `mapstructure:"block_deadline"`
+}
+
+// Node - pool of nodes, calls fail over between endpoints and TronGrid is the last resort
+type Node struct {
+	// FullNodeURL - added to pool as unlimited full node when it is not listed in endpoints
+	FullNodeURL string     `mapstructure:"full_node_url"`
+	Endpoints   []Endpoint `mapstructure:"endpoints"`
+	// TrongridKeys - API keys rotated per request to TronGrid
+	TrongridKeys []string `mapstructure:"trongrid_keys"`
+	// TrongridRateLimit - requests per second of single key, limit of TronGrid is multiplied by number of keys
+	TrongridRateLimit float64 `mapstructure:"trongrid_rate_limit"`
+	// Cooldown - endpoint is skipped after consecutive failures or 429, doubles with further failures
+	Cooldown time.Duration `mapstructure:"cooldown"`
+	Retry    Retry         `mapstructure:"retry"`
+}
+
+// Health - thresholds of /healthz and /readyz
+type Health struct {
+	// StaleAfter - liveness fails when main loop didn't fetch or commit a message for this long
+	// while it is busy or consumer group has lag, 0 disables the check
+	StaleAfter time.Duration `mapstructure:"stale_after"`
+	// CheckTimeout - timeout of every readiness check
+	CheckTimeout time.Duration `mapstructure:"check_timeout"`
+}
+
+// Tracing - OpenTelemetry exporter, OTLP endpoint is set by OTEL_EXPORTER_OTLP_ENDPOINT
+type Tracing struct {
+	Exporter    models.TracingExporter `mapstructure:"exporter"`
+	SampleRatio float64                `mapstructure:"sample_ratio"`
+}
+
+// HTTP - server of runway with metrics and probes
+type HTTP struct {
+	Addr string `mapstructure:"addr"`
+	// AdminAddr - separate server of /parse and /log/level, loopback by default, empty disables it
+	AdminAddr string `mapstructure:"admin_addr"`
+	Health    Health `mapstructure:"health"`
+}
+
+type Config struct {
+	Mode    models.Mode `mapstructure:"mode"`
+	Kafka   Kafka       `mapstructure:"kafka"`
+	Redis   Redis       `mapstructure:"redis"`
+	Node    Node        `mapstructure:"node"`
+	HTTP    HTTP        `mapstructure:"http"`
+	Tracing Tracing     `mapstructure:"tracing"`
+	// LogLevel - initial level, can be changed at runtime via /log/level
+	LogLevel string `mapstructure:"log_level"`
+	// ShutdownTimeout - time to finish in-flight block on shutdown, keep below pod termination grace period
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+}
+
+func setDefaults() {
+	viper.SetDefault("mode", string(models.LIVE))
+	viper.SetDefault("kafka.group_id", "parsers")
+	viper.SetDefault("kafka.topic_prefix", "")
+	viper.SetDefault("kafka.key_strategy", string(models.KeyByBlock))
+	viper.SetDefault("kafka.encoding", string(models.MsgPack))
+	viper.SetDefault("kafka.topics.live", string(models.TronLive))
+	viper.SetDefault("kafka.topics.history", string(models.TronHistory))
+	viper.SetDefault("kafka.topics.prices", string(models.TronPrices))
+	viper.SetDefault("kafka.topics.failed", string(models.FailedBlocks))
+	viper.SetDefault("kafka.topics.dead", string(models.DeadBlocks))
+	viper.SetDefault("kafka.topics.parsed", string(models.Parsed))
+	viper.SetDefault("kafka.topics.holders", string(models.Holders))
+	viper.SetDefault("kafka.fan_out.enabled", false)
+	viper.SetDefault("kafka.fan_out.batch", false)
+	viper.SetDefault("kafka.fan_out.topics.pair_swaps", "parser.sys.pair_swaps")
+	viper.SetDefault("kafka.fan_out.topics.direct_swaps", "parser.sys.direct_swaps")
+	viper.SetDefault("kafka.fan_out.topics.liquidities", "parser.sys.liquidity_events")
+	viper.SetDefault("kafka.fan_out.topics.pairs", "parser.sys.new_pairs")
+	viper.SetDefault("kafka.fan_out.topics.transfers", "parser.sys.transfer_events")
+	viper.SetDefault("kafka.transactions.enabled", false)
+	viper.SetDefault("kafka.transactions.timeout", time.Minute)
+	viper.SetDefault("kafka.producer.max_message_bytes", 900e3) // 900KB, broker default limit is 1MB
+	viper.SetDefault("kafka.producer.compression", string(models.CompressionNone))
+	viper.SetDefault("kafka.reader.min_bytes", 1e3)  // 1KB
+	viper.SetDefault("kafka.reader.max_bytes", 50e6) // 50MB
+	viper.SetDefault("kafka.reader.max_wait", time.Second)
+	viper.SetDefault("redis.db", 0)
+	viper.SetDefault("node.trongrid_rate_limit", 10.0)
+	viper.SetDefault("node.cooldown", 30*time.Second)
+	viper.SetDefault("node.retry.attempts", 6)
+	viper.SetDefault("node.retry.initial_backoff", time.Second)
+	viper.SetDefault("node.retry.max_backoff", 15*time.Second)
+	viper.SetDefault("node.retry.block_deadline", 2*time.Minute)
+	viper.SetDefault("http.addr", ":8080")
+	viper.SetDefault("http.admin_addr", "127.0.0.1:8081")
+	viper.SetDefault("shutdown_timeout", 20*time.Second)
+	viper.SetDefault("log_level", "info")
+	viper.SetDefault("tracing.exporter", string(models.TracingNone))
+	viper.SetDefault("tracing.sample_ratio", 1.0)
+	viper.SetDefault("http.health.stale_after", 10*time.Minute)
+	viper.SetDefault("http.health.check_timeout", 3*time.Second)
+}
+
+func bindEnv() error {
+	envs := map[string]string{
+		"kafka.brokers":                    "KAFKA",
+		"kafka.group_id":                   "KAFKA_GROUP_ID",
+		"kafka.topic_prefix":               "KAFKA_TOPIC_PREFIX",
+		"kafka.key_strategy":               "KAFKA_KEY_STRATEGY",
+		"kafka.encoding":                   "KAFKA_ENCODING",
+		"kafka.fan_out.enabled":            "KAFKA_FAN_OUT",
+		"kafka.fan_out.batch":              "KAFKA_FAN_OUT_BATCH",
+		"kafka.transactions.enabled":       "KAFKA_TRANSACTIONS",
+		"kafka.transactions.id":            "KAFKA_TRANSACTIONAL_ID",
+		"kafka.producer.max_message_bytes": "KAFKA_MAX_MESSAGE_BYTES",
+		"kafka.producer.compression":       "KAFKA_COMPRESSION",
+		"redis.addr":                       "REDIS_ADDR",
+		"redis.password":                   "REDIS_PASSWORD",
+		"redis.db":                         "REDIS_DB",
+		"node.full_node_url":               "FULL_NODE_URL",
+		"node.trongrid_keys":               "TRONGRID_API_KEYS",
+		"node.trongrid_rate_limit":         "TRONGRID_RATE_LIMIT",
+		"node.cooldown":                    "NODE_COOLDOWN",
+		"node.retry.attempts":              "NODE_RETRY_ATTEMPTS",
+		"node.retry.initial_backoff":       "NODE_RETRY_BACKOFF",
+		"node.retry.max_backoff":           "NODE_RETRY_MAX_BACKOFF",
+		"node.retry.block_deadline":        "NODE_BLOCK_DEADLINE",
+		"http.addr":                        "HTTP_ADDR",
+		"http.admin_addr":                  "ADMIN_ADDR",
+		"shutdown_timeout":                 "SHUTDOWN_TIMEOUT",
+		"log_level":                        "LOG_LEVEL",
+		"tracing.exporter":                 "TRACING_EXPORTER",
+		"tracing.sample_ratio":             "TRACING_SAMPLE_RATIO",
+		"http.health.stale_after":          "HEALTH_STALE_AFTER",
+		"http.health.check_timeout":        "HEALTH_CHECK_TIMEOUT",
+		"kafka.reader.max_wait":            "KAFKA_READER_MAX_WAIT",
+	}
+	for key, env := range envs {
+		if err := viper.BindEnv(key, env); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load - read and validate configuration of consumer, file path is taken from "config" key.
+// Config is never nil, so logger can be created before error is reported
+func Load() (*Config, error) {
+	cfg, err := Read()
+	if err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.Validate()
+}
+
+// Read - read configuration without validation, commands validate sections they use
+func Read() (*Config, error) {
+	cfg := &Config{}
+	setDefaults()
+	if err := bindEnv(); err != nil {
+		return cfg, err
+	}
+
+	if path := viper.GetString("config"); path != "" {
+		viper.SetConfigFile(path)
+		if err := viper.ReadInConfig(); err != nil {
+			return cfg, fmt.Errorf("read config file: %w", err)
+		}
+	}
+
+	if err := viper.Unmarshal(cfg); err != nil {
+		return cfg, fmt.Errorf("decode config: %w", err)
+	}
+	if cfg.Kafka.Transactions.Enabled && cfg.Kafka.Transactions.ID == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return cfg, fmt.Errorf("resolve transactional id: %w", err)
+		}
+		cfg.Kafka.Transactions.ID = fmt.Sprintf("%s-%s", cfg.Kafka.GroupID, hostname)
+	}
+	return cfg, nil
+}
+
+// Validate - check configuration on startup
+func (c *Config) Validate() error {
+	var errs []error
+
+	switch c.Mode {
+	case models.LIVE, models.HISTORY, models.PRICES, models.RETRY:
+	default:
+		errs = append(errs, fmt.Errorf("unknown mode %q", c.Mode))
+	}
+	if len(c.Kafka.Brokers) == 0 || c.Kafka.Brokers[0] == "" {
+		errs = append(errs, errors.New("kafka brokers are required (KAFKA)"))
+	}
+	switch c.Kafka.KeyStrategy {
+	case models.KeyByBlock, models.KeyByNetwork, models.KeyNone:
+	default:
+		errs = append(errs, fmt.Errorf("unknown kafka key strategy %q", c.Kafka.KeyStrategy))
+	}
+	if _, err := encoding.New(c.Kafka.Encoding); err != nil {
+		errs = append(errs, err)
+	}
+	if c.Kafka.GroupID == "" {
+		errs = append(errs, errors.New("kafka group id is required"))
+	}
+	for name, topic := range map[string]string{
+		"live":    c.Kafka.Topics.Live,
+		"history": c.Kafka.Topics.History,
+		"prices":  c.Kafka.Topics.Prices,
+		"failed":  c.Kafka.Topics.Failed,
+		"dead":    c.Kafka.Topics.Dead,
+		"parsed":  c.Kafka.Topics.Parsed,
+		"holders": c.Kafka.Topics.Holders,
+	} {
+		if topic == "" {
+			errs = append(errs, fmt.Errorf("kafka topic %s is required", name))
+		}
+	}
+	if c.Kafka.FanOut.Enabled {
+		for name, topic := range map[string]string{
+			"pair_swaps":   c.Kafka.FanOut.Topics.PairSwaps,
+			"direct_swaps": c.Kafka.FanOut.Topics.DirectSwaps,
+			"liquidities":  c.Kafka.FanOut.Topics.Liquidities,
+			"pairs":        c.Kafka.FanOut.Topics.Pairs,
+			"transfers":    c.Kafka.FanOut.Topics.Transfers,
+		} {
+			if topic == "" {
+				errs = append(errs, fmt.Errorf("kafka fan-out topic %s is required", name))
+			}
+		}
+	}
+	if c.Kafka.Transactions.Enabled {
+		if c.Kafka.Transactions.ID == "" {
+			errs = append(errs, errors.New("kafka transactional id is required (KAFKA_TRANSACTIONAL_ID)"))
+		}
+		if c.Kafka.Transactions.Timeout <= 0 {
+			errs = append(errs, errors.New("kafka transaction timeout should be positive"))
+		}
+	}
+	if c.Kafka.Producer.MaxMessageBytes <= 0 {
+		errs = append(errs, errors.New("kafka producer max_message_bytes should be positive"))
+	}
+	switch c.Kafka.Producer.Compression {
+	case models.CompressionNone, models.CompressionSnappy, models.CompressionZstd:
+	default:
+		errs = append(errs, fmt.Errorf("unknown kafka compression %q", c.Kafka.Producer.Compression))
+	}
+	if c.Kafka.Reader.MinBytes <= 0 || c.Kafka.Reader.MaxBytes < c.Kafka.Reader.MinBytes {
+		errs = append(errs, errors.New("kafka reader bytes should satisfy 0 < min_bytes <= max_bytes"))
+	}
+	if c.Kafka.Reader.MaxWait <= 0 {
+		errs = append(errs, errors.New("kafka reader max_wait should be positive"))
+	}
+	if c.HTTP.Addr == "" {
+		errs = append(errs, errors.New("http address is required (HTTP_ADDR)"))
+	}
+	if c.HTTP.AdminAddr != "" && c.HTTP.AdminAddr == c.HTTP.Addr {
+		errs = append(errs, errors.New("admin address should differ from http address (ADMIN_ADDR)"))
+	}
+	if c.HTTP.Health.StaleAfter < 0 || c.HTTP.Health.CheckTimeout <= 0 {
+		errs = append(errs, errors.New("health stale_after should not be negative and check_timeout should be positive"))
+	}
+	if c.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("shutdown_timeout should be positive"))
+	}
+	errs = append(errs, c.ValidateParser())
+	return errors.Join(errs...)
+}
+
+// ValidateParser - check sections used to parse blocks, commands without kafka validate only them
+func (c *Config) ValidateParser() error {
+	var errs []error
+	switch c.Tracing.Exporter {
+	case models.TracingNone, models.TracingStdout, models.TracingOTLP:
+	default:
+		errs = append(errs, fmt.Errorf("unknown tracing exporter %q", c.Tracing.Exporter))
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errs = append(errs, errors.New("tracing sample_ratio should be within [0, 1]"))
+	}
+	if _, err := zapcore.ParseLevel(c.LogLevel); err != nil {
+		errs = append(errs, err)
+	}
+	if c.Redis.Addr == "" {
+		errs = append(errs, errors.New("redis address is required (REDIS_ADDR)"))
+	}
+	for i, endpoint := range c.Node.Endpoints {
+		if endpoint.URL == "" {
+			errs = append(errs, fmt.Errorf("node endpoint %d: url is required", i))
+		}
+		switch endpoint.Kind {
+		case "", models.FullNode, models.SolidityNode:
+		default:
+			errs = append(errs, fmt.Errorf("node endpoint %d: unknown kind %q", i, endpoint.Kind))
+		}
+		if endpoint.RateLimit < 0 {
+			errs = append(errs, fmt.Errorf("node endpoint %d: rate_limit should not be negative", i))
+		}
+	}
+	if c.Node.TrongridRateLimit <= 0 {
+		errs = append(errs, errors.New("node trongrid_rate_limit should be positive"))
+	}
+	if c.Node.Cooldown <= 0 {
+		errs = append(errs, errors.New("node cooldown should be positive"))
+	}
+	if retry := c.Node.Retry; retry.Attempts < 1 || retry.InitialBackoff <= 0 || retry.MaxBackoff < retry.InitialBackoff {
+		errs = append(errs, errors.New("node retry should satisfy attempts >= 1 and 0 < initial_backoff <= max_backoff"))
+	}
+	if c.Node.Retry.BlockDeadline < 0 {
+		errs = append(errs, errors.New("node block_deadline should not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// Topic - apply prefix to topic name
+func (k *Kafka) Topic(name string) string {
+	return k.TopicPrefix + name
+}
+
+// EntityTopics - prefixed fan-out topics by entity
+func (k *Kafka) EntityTopics() map[models.Entity]string {
+	return map[models.Entity]string{
+		models.PairSwaps:   k.Topic(k.FanOut.Topics.PairSwaps),
+		models.DirectSwaps: k.Topic(k.FanOut.Topics.DirectSwaps),
+		models.Liquidities: k.Topic(k.FanOut.Topics.Liquidities),
+		models.Pairs:       k.Topic(k.FanOut.Topics.Pairs),
+		models.Transfers:   k.Topic(k.FanOut.Topics.Transfers),
+	}
+}
+
+// InputTopic - topic consumed in mode
+func (c *Config) InputTopic() string {
+	return c.ModeTopic(c.Mode)
+}
+
+// ModeTopic - prefixed topic consumed by workers of mode
+func (c *Config) ModeTopic(mode models.Mode) string {
+	switch mode {
+	case models.HISTORY:
+		return c.Kafka.Topic(c.Kafka.Topics.History)
+	case models.PRICES:
+		return c.Kafka.Topic(c.Kafka.Topics.Prices)
+	case models.RETRY:
+		return c.Kafka.Topic(c.Kafka.Topics.Failed)
+	default:
+		return c.Kafka.Topic(c.Kafka.Topics.Live)
+	}
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kattana-io/tron-blocks-parser/internal/models"
+	"github.com/spf13/viper"
+)
+
+var validRetry = Retry{Attempts: 6, InitialBackoff: time.Second, MaxBackoff: 15 * time.Second, BlockDeadline: 2 * time.Minute}
+
+func createValidConfig() *Config {
+	return &Config{
+		Mode: models.LIVE,
+		Kafka: Kafka{
+			Brokers: []string{"localhost:9092"},
+			GroupID: "parsers",
+			Topics: Topics{
+				Live: "live", History: "history", Prices: "prices",
+				Failed: "failed", Dead: "dead", Parsed: "parsed", Holders: "holders",
+			},
+			Reader:      Reader{MinBytes: 1e3, MaxBytes: 50e6, MaxWait: time.Second},
+			KeyStrategy: models.KeyByBlock,
+			Encoding:    models.MsgPack,
+			Producer:    Producer{MaxMessageBytes: 900e3, Compression: models.CompressionNone},
+		},
+		Redis:           Redis{Addr: "127.0.0.1:6379"},
+		Node:            Node{TrongridRateLimit: 10, Cooldown: 30 * time.Second, Retry: validRetry},
+		ShutdownTimeout: 20 * time.Second,
+		Tracing:         Tracing{Exporter: models.TracingNone, SampleRatio: 1},
+		LogLevel:        "info",
+		HTTP:            HTTP{Addr: ":8080", AdminAddr: "127.0.0.1:8081", Health: Health{StaleAfter: 10 * time.Minute, CheckTimeout: 3 * time.Second}},
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		wantErr bool
+	}{
+		{name: "Valid config", modify: func(c *Config) {}, wantErr: false},
+		{name: "Unknown mode", modify: func(c *Config) { c.Mode = "FAST" }, wantErr: true},
+		{name: "No brokers", modify: func(c *Config) { c.Kafka.Brokers = []string{""} }, wantErr: true},
+		{name: "Empty topic", modify: func(c *Config) { c.Kafka.Topics.Holders = "" }, wantErr: true},
+		{name: "Invalid reader", modify: func(c *Config) { c.Kafka.Reader.MaxBytes = 1 }, wantErr: true},
+		{name: "Unknown key strategy", modify: func(c *Config) { c.Kafka.KeyStrategy = "random" }, wantErr: true},
+		{name: "Unknown encoding", modify: func(c *Config) { c.Kafka.Encoding = "xml" }, wantErr: true},
+		{name: "Fan-out without topics", modify: func(c *Config) { c.Kafka.FanOut.Enabled = true }, wantErr: true},
+		{name: "Transactions without id", modify: func(c *Config) {
+			c.Kafka.Transactions = Transactions{Enabled: true, Timeout: time.Minute}
+		}, wantErr: true},
+		{name: "Unknown compression", modify: func(c *Config) { c.Kafka.Producer.Compression = "gzip" }, wantErr: true},
+		{name: "Invalid sample ratio", modify: func(c *Config) { c.Tracing.SampleRatio = 2 }, wantErr: true},
+		{name: "Unknown log level", modify: func(c *Config) { c.LogLevel = "verbose" }, wantErr: true},
+		{name: "No redis", modify: func(c *Config) { c.Redis.Addr = "" }, wantErr: true},
+		{name: "Admin on http address", modify: func(c *Config) { c.HTTP.AdminAddr = ":8080" }, wantErr: true},
+		{name: "Admin disabled", modify: func(c *Config) { c.HTTP.AdminAddr = "" }, wantErr: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := createValidConfig()
+			tt.modify(c)
+			if err := c.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateParser(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		wantErr bool
+	}{
+		{name: "Without kafka", modify: func(c *Config) { c.Kafka = Kafka{} }, wantErr: false},
+		{name: "No redis", modify: func(c *Config) { c.Redis.Addr = "" }, wantErr: true},
+		{name: "Unknown tracing exporter", modify: func(c *Config) { c.Tracing.Exporter = "jaeger" }, wantErr: true},
+		{name: "Node endpoints", modify: func(c *Config) {
+			c.Node.Endpoints = []Endpoint{{URL: "http://full:8090"}, {URL: "http://solidity:8091", Kind: models.SolidityNode, RateLimit: 20}}
+		}, wantErr: false},
+		{name: "Node endpoint without url", modify: func(c *Config) { c.Node.Endpoints = []Endpoint{{Kind: models.FullNode}} }, wantErr: true},
+		{name: "Unknown node kind", modify: func(c *Config) {
+			c.Node.Endpoints = []Endpoint{{URL: "http://full:8090", Kind: "archive"}}
+		}, wantErr: true},
+		{name: "No node cooldown", modify: func(c *Config) { c.Node.Cooldown = 0 }, wantErr: true},
+		{name: "Without retries", modify: func(c *Config) { c.Node.Retry.Attempts = 1 }, wantErr: false},
+		{name: "No retry attempts", modify: func(c *Config) { c.Node.Retry.Attempts = 0 }, wantErr: true},
+		{name: "Max backoff below initial", modify: func(c *Config) { c.Node.Retry.MaxBackoff = time.Millisecond }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := createValidConfig()
+			tt.modify(c)
+			if err := c.ValidateParser(); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateParser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_InputTopic(t *testing.T) {
+	c := createValidConfig()
+	c.Kafka.TopicPrefix = "staging."
+	c.Mode = models.RETRY
+	if got := c.InputTopic(); got != "staging.failed" {
+		t.Errorf("InputTopic() = %v, want %v", got, "staging.failed")
+	}
+}
+
+func TestLoad_Error(t *testing.T) {
+	// runway is created from config before the error is reported, so config is never nil
+	viper.Set("config", filepath.Join(t.TempDir(), "missing.yaml"))
+	defer viper.Reset()
+
+	cfg, err := Load()
+	if err == nil {
+		t.Errorf("Load() expected error for missing config file")
+	}
+	if cfg == nil {
+		t.Fatalf("Load() config is nil on error")
+	}
+}
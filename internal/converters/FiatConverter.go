@@ -7,6 +7,7 @@ import (
 	"github.com/goccy/go-json"
 	commonModels "github.com/kattana-io/models/pkg/storage"
 	"github.com/kattana-io/tron-blocks-parser/internal/models"
+	"github.com/kattana-io/tron-blocks-parser/internal/tracing"
 	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 	"math/big"
@@ -17,15 +18,18 @@ import (
 type FiatConverter struct {
 	redis            *redis.Client
 	Prices           map[string]decimal.Decimal `json:"prices"`
+	Origins          map[string]Provenance      `json:"origins,omitempty"`
 	supported        map[string]bool
 	pairs            map[string]bool
 	flips            map[string]bool
 	stableCoinsList  map[string]bool
 	rawQuotes        []models.QuotePair
+	blockPrices      *blockPrices
 	stableCoinsMutex sync.Mutex
 	ratesMutex       sync.RWMutex
 	listMutex        sync.RWMutex
 	block            *commonModels.Block
+	mode             models.Mode
 	log              *zap.Logger
 }
 
@@ -34,41 +38,55 @@ const (
 	RedisTimeout = 30
 )
 
-func CreateConverter(client *redis.Client, log *zap.Logger, block *commonModels.Block, rawQuotes []models.QuotePair) *FiatConverter {
+func CreateConverter(ctx context.Context,
+	client *redis.Client,
+	log *zap.Logger,
+	block *commonModels.Block,
+	rawQuotes []models.QuotePair,
+	mode models.Mode) *FiatConverter {
 	converter := &FiatConverter{
 		log:             log,
 		redis:           client,
 		block:           block,
+		mode:            mode,
 		Prices:          make(map[string]decimal.Decimal, 0),
+		Origins:         make(map[string]Provenance, 0),
 		flips:           make(map[string]bool, 0),
 		supported:       make(map[string]bool, 0),
 		pairs:           make(map[string]bool, 0),
 		stableCoinsList: make(map[string]bool, 0),
 		rawQuotes:       rawQuotes,
+		blockPrices:     newBlockPrices(),
 	}
 
 	for _, quote := range converter.rawQuotes {
 		if quote.Kind == StableCoin {
 			converter.Prices[quote.Token] = decimal.NewFromInt(1)
+			converter.setOrigin(quote.Token, SourceStable, "")
 			converter.stableCoinsList[quote.Token] = true
 		}
 		converter.supported[quote.Token] = true
 	}
 
 	if block.Notify {
-		converter.readLastPrices()
+		converter.readLastPrices(ctx)
 	}
 
-	converter.readPreviousBlockPricesFromCache()
+	// History and retry workers run in parallel, so they rely only on checkpoints,
+	// prices worker chains through its own checkpoints, which unlike block cache don't expire
+	if mode == models.HISTORY || mode == models.RETRY || mode == models.PRICES ||
+		!converter.readPreviousBlockPricesFromCache(ctx) {
+		converter.readNearestSnapshot(ctx)
+	}
 	return converter
 }
 
 func (f *FiatConverter) Update(pair, tokenA, tokenB string, price decimal.Decimal) {
 	if f.updateable(pair) {
 		if f.ShouldFlip(pair) {
-			f.updatePrice(tokenB, price, true)
+			f.updatePrice(pair, tokenB, price, true)
 		} else {
-			f.updatePrice(tokenA, price, false)
+			f.updatePrice(pair, tokenA, price, false)
 		}
 	}
 }
@@ -91,56 +109,23 @@ func (f *FiatConverter) ShouldFlip(address string) bool {
 	return false
 }
 
-func (f *FiatConverter) GetPriceOfToken(token string) decimal.Decimal {
+func (f *FiatConverter) GetPriceOfToken(token string) (decimal.Decimal, Provenance) {
 	if f.isTokenStable(token) {
-		return decimal.NewFromInt(1)
+		return decimal.NewFromInt(1), Provenance{Source: SourceStable}
 	}
 	if f.Convertable(token) {
-		return f.getRate(token)
+		return f.getRate(token), f.getOrigin(token)
 	}
-	return decimal.NewFromInt(0)
+	return decimal.NewFromInt(0), Provenance{}
 }
 
-func (f *FiatConverter) Convert(tokenA, tokenB string, price decimal.Decimal) decimal.Decimal {
-	if f.isTokenStable(tokenA) {
-		if !price.IsZero() {
-			return decimal.NewFromInt(1).Div(price)
-		}
-	}
-	if f.Convertable(tokenB) {
-		return price.Mul(f.getRate(tokenB))
-	}
-	return decimal.NewFromInt(0)
-}
+func (f *FiatConverter) Commit(ctx context.Context) {
+	ctx, span := tracing.Start(ctx, "prices.Commit")
+	defer span.End()
 
-// ConvertAB - return both prices
-func (f *FiatConverter) ConvertAB(tokenA, tokenB string, price decimal.Decimal) (priceAUSD, priceBUSD decimal.Decimal) {
-	if !price.IsZero() {
-		if f.isTokenStable(tokenA) {
-			return decimal.NewFromInt(1), decimal.NewFromInt(1).Div(price)
-		}
-		if f.isTokenStable(tokenB) {
-			return price, decimal.NewFromInt(1)
-		}
-	}
-	if f.Convertable(tokenB) {
-		rateB := f.getRate(tokenB)
-		return price.Mul(rateB), rateB
-	}
-	if f.Convertable(tokenA) {
-		rateA := f.getRate(tokenA)
-		if !price.IsZero() {
-			return rateA, rateA.Div(price)
-		}
-		return rateA, decimal.NewFromInt(0)
-	}
-	return
-}
-
-func (f *FiatConverter) Commit() {
-	// Update live
-	if f.block.Notify {
-		f.writeLastPrices()
+	// Update live, retried blocks are behind the head and would overwrite fresh prices
+	if f.block.Notify && f.mode != models.RETRY {
+		f.writeLastPrices(ctx)
 	}
 
 	// Update block prices
@@ -148,9 +133,23 @@ func (f *FiatConverter) Commit() {
 
 	key := cacheKey(f.block.Network, f.block.Number.String())
 
-	if err := f.redis.Set(context.Background(), key, b, time.Second*RedisTimeout).Err(); err != nil {
+	_, setSpan := tracing.Start(ctx, "prices.writeBlockPrices")
+	err := f.redis.Set(ctx, key, b, time.Second*RedisTimeout).Err()
+	tracing.End(setSpan, err)
+	if err != nil {
 		f.log.Error(err.Error())
 	}
+
+	// Persist checkpoint for history lookups
+	if f.shouldSnapshot() {
+		if err := f.writeSnapshot(ctx, b); err != nil {
+			f.log.Error(err.Error())
+		} else if f.mode == models.PRICES {
+			if err := f.repairSnapshots(ctx, b); err != nil {
+				f.log.Error(err.Error())
+			}
+		}
+	}
 }
 
 func (f *FiatConverter) updateable(pair string) bool {
@@ -162,7 +161,7 @@ func (f *FiatConverter) updateable(pair string) bool {
 	return false
 }
 
-func (f *FiatConverter) updatePrice(token string, price decimal.Decimal, flipped bool) {
+func (f *FiatConverter) updatePrice(pair, token string, price decimal.Decimal, flipped bool) {
 	f.ratesMutex.Lock()
 	defer f.ratesMutex.Unlock()
 
@@ -171,6 +170,7 @@ func (f *FiatConverter) updatePrice(token string, price decimal.Decimal, flipped
 	} else {
 		f.Prices[token] = price
 	}
+	f.setOrigin(token, SourceBlock, pair)
 }
 
 func (f *FiatConverter) getRate(token string) decimal.Decimal {
@@ -198,11 +198,14 @@ func cacheKey(network, number string) string {
 	return fmt.Sprintf("parser:prices:%s:%s", network, number)
 }
 
-func (f *FiatConverter) readPreviousBlockPricesFromCache() bool {
+func (f *FiatConverter) readPreviousBlockPricesFromCache(ctx context.Context) bool {
 	blockNumber := big.NewInt(0).Sub(f.block.Number, big.NewInt(1)) // previous block
 	key := cacheKey(f.block.Network, blockNumber.String())
 
-	val, err := f.redis.Get(context.Background(), key).Bytes()
+	ctx, span := tracing.Start(ctx, "prices.readPreviousBlock")
+	defer span.End()
+
+	val, err := f.redis.Get(ctx, key).Bytes()
 	if err == redis.Nil || err != nil {
 		return false
 	}
@@ -214,14 +217,17 @@ func (f *FiatConverter) readPreviousBlockPricesFromCache() bool {
 	}
 
 	f.Prices = cached.Prices
+	f.restoreOrigins(cached.Origins, SourcePrevious, 0)
 	return true
 }
 
-func (f *FiatConverter) UpdateTokenUSDPrice(address string, price decimal.Decimal) {
+// UpdateTokenUSDPrice - set USD rate of token defined by pair
+func (f *FiatConverter) UpdateTokenUSDPrice(address, pair string, price decimal.Decimal) {
 	f.ratesMutex.Lock()
 	defer f.ratesMutex.Unlock()
 
 	if !f.isTokenStable(address) {
 		f.Prices[address] = price
+		f.setOrigin(address, SourceBlock, pair)
 	}
 }
@@ -0,0 +1,79 @@
+package converters
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+/**
+ * Conversion of pair prices into USD
+ * price is always a price of tokenA denominated in tokenB
+ */
+
+// Tolerance - allowed relative difference between USD prices ratio and pair price
+var Tolerance = decimal.NewFromFloat(0.05)
+
+// Conversion - USD prices of both tokens of the pair
+type Conversion struct {
+	PriceAUSD decimal.Decimal
+	PriceBUSD decimal.Decimal
+	OriginA   Provenance
+	OriginB   Provenance
+	// Deviation - relative difference between PriceAUSD/PriceBUSD and pair price, zero unless both rates are known
+	Deviation decimal.Decimal
+	// Consistent - false when Deviation exceeds Tolerance
+	Consistent bool
+}
+
+// ConvertAB - return USD prices of both tokens. Against a stablecoin the other side is priced by the trade,
+// an unknown side is derived from the known one. When both rates are known, each side keeps its own rate
+// and Deviation reports how far they are from the trade
+func (f *FiatConverter) ConvertAB(tokenA, tokenB string, price decimal.Decimal) Conversion {
+	rateA, originA := f.GetPriceOfToken(tokenA)
+	rateB, originB := f.GetPriceOfToken(tokenB)
+	knownA := !rateA.IsZero()
+	knownB := !rateB.IsZero()
+
+	result := Conversion{
+		PriceAUSD:  rateA,
+		PriceBUSD:  rateB,
+		OriginA:    originA,
+		OriginB:    originB,
+		Consistent: true,
+	}
+
+	if price.IsZero() {
+		return result
+	}
+
+	if knownA && knownB {
+		implied := rateA.Div(rateB)
+		result.Deviation = implied.Sub(price).Abs().Div(price)
+		result.Consistent = result.Deviation.LessThanOrEqual(Tolerance)
+	}
+
+	stableA := originA.Source == SourceStable
+	stableB := originB.Source == SourceStable
+	switch {
+	case stableA && stableB:
+	case stableB:
+		result.PriceAUSD = price.Mul(rateB)
+		result.OriginA = originB
+	case stableA:
+		result.PriceBUSD = rateA.Div(price)
+		result.OriginB = originA
+	case knownA && knownB:
+		// own rates may be fresher than this trade, disagreement is reported by Deviation
+	case knownB:
+		result.PriceAUSD = price.Mul(rateB)
+		result.OriginA = originB
+	case knownA:
+		result.PriceBUSD = rateA.Div(price)
+		result.OriginB = originA
+	}
+	return result
+}
+
+// Convert - return USD price of tokenA
+func (f *FiatConverter) Convert(tokenA, tokenB string, price decimal.Decimal) decimal.Decimal {
+	return f.ConvertAB(tokenA, tokenB, price).PriceAUSD
+}
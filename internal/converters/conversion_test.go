@@ -0,0 +1,172 @@
+package converters
+
+import (
+	"testing"
+	"testing/quick"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	testTokenA = "TokenA"
+	testTokenB = "TokenB"
+)
+
+// createTestConverter - converter without cache, knows only passed rates
+func createTestConverter(stable []string, rates map[string]decimal.Decimal) *FiatConverter {
+	f := &FiatConverter{
+		Prices:          make(map[string]decimal.Decimal),
+		Origins:         make(map[string]Provenance),
+		supported:       make(map[string]bool),
+		stableCoinsList: make(map[string]bool),
+	}
+	for _, token := range stable {
+		f.stableCoinsList[token] = true
+		f.supported[token] = true
+		f.Prices[token] = decimal.NewFromInt(1)
+	}
+	for token, rate := range rates {
+		f.supported[token] = true
+		f.Prices[token] = rate
+		f.setOrigin(token, SourceBlock, "pair-"+token)
+	}
+	return f
+}
+
+// toDecimal - map random numbers into positive decimals
+func toDecimal(n uint32) decimal.Decimal {
+	return decimal.NewFromInt(int64(n%1_000_000) + 1).Div(decimal.NewFromInt(1000))
+}
+
+func closeTo(a, b decimal.Decimal) bool {
+	if b.IsZero() {
+		return a.IsZero()
+	}
+	return a.Sub(b).Abs().Div(b).LessThan(decimal.New(1, -8))
+}
+
+func TestConvertAB_OwnRatesAreKept(t *testing.T) {
+	property := func(rawA, rawB, rawPrice uint32) bool {
+		rateA, rateB, price := toDecimal(rawA), toDecimal(rawB), toDecimal(rawPrice)
+		f := createTestConverter(nil, map[string]decimal.Decimal{testTokenA: rateA, testTokenB: rateB})
+
+		conv := f.ConvertAB(testTokenA, testTokenB, price)
+		return conv.PriceAUSD.Equal(rateA) &&
+			conv.PriceBUSD.Equal(rateB) &&
+			conv.OriginA.Pair == "pair-"+testTokenA &&
+			conv.OriginB.Pair == "pair-"+testTokenB &&
+			closeTo(conv.Deviation, rateA.Div(rateB).Sub(price).Abs().Div(price))
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestConvertAB_DerivesUnknownSide(t *testing.T) {
+	property := func(rawRate, rawPrice uint32, knownA bool) bool {
+		rate, price := toDecimal(rawRate), toDecimal(rawPrice)
+		known := testTokenB
+		if knownA {
+			known = testTokenA
+		}
+		f := createTestConverter(nil, map[string]decimal.Decimal{known: rate})
+
+		conv := f.ConvertAB(testTokenA, testTokenB, price)
+		if !conv.Consistent || conv.OriginA != conv.OriginB {
+			return false
+		}
+		if knownA {
+			return conv.PriceAUSD.Equal(rate) && closeTo(conv.PriceBUSD.Mul(price), rate)
+		}
+		return conv.PriceBUSD.Equal(rate) && conv.PriceAUSD.Equal(price.Mul(rate))
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestConvertAB_StableLegs(t *testing.T) {
+	property := func(rawPrice uint32, stableA bool) bool {
+		price := toDecimal(rawPrice)
+		stable := testTokenB
+		if stableA {
+			stable = testTokenA
+		}
+		f := createTestConverter([]string{stable}, nil)
+
+		conv := f.ConvertAB(testTokenA, testTokenB, price)
+		if stableA {
+			// USD price of tokenA is always 1, tokenB costs 1/price
+			return conv.PriceAUSD.Equal(decimal.NewFromInt(1)) &&
+				closeTo(conv.PriceBUSD, decimal.NewFromInt(1).Div(price)) &&
+				f.Convert(testTokenA, testTokenB, price).Equal(decimal.NewFromInt(1)) &&
+				conv.OriginB.Source == SourceStable
+		}
+		return conv.PriceAUSD.Equal(price) &&
+			conv.PriceBUSD.Equal(decimal.NewFromInt(1)) &&
+			conv.OriginA.Source == SourceStable
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestConvertAB_StableLegWithKnownRate(t *testing.T) {
+	tests := []struct {
+		name      string
+		stable    string
+		rate      string
+		price     string
+		wantA     string
+		wantB     string
+		wantValid bool
+	}{
+		{name: "Token/stable follows trade", stable: testTokenB, rate: testTokenA, price: "2", wantA: "2", wantB: "1", wantValid: false},
+		{name: "Stable/token follows trade", stable: testTokenA, rate: testTokenB, price: "0.5", wantA: "1", wantB: "2", wantValid: false},
+		{name: "Trade close to cached rate", stable: testTokenB, rate: testTokenA, price: "1.01", wantA: "1.01", wantB: "1", wantValid: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// cached rate of the other token is 1
+			f := createTestConverter([]string{tt.stable}, map[string]decimal.Decimal{tt.rate: decimal.NewFromInt(1)})
+			conv := f.ConvertAB(testTokenA, testTokenB, decimal.RequireFromString(tt.price))
+			if !conv.PriceAUSD.Equal(decimal.RequireFromString(tt.wantA)) || !conv.PriceBUSD.Equal(decimal.RequireFromString(tt.wantB)) {
+				t.Errorf("ConvertAB() = %v, %v, want %v, %v", conv.PriceAUSD, conv.PriceBUSD, tt.wantA, tt.wantB)
+			}
+			if conv.Consistent != tt.wantValid {
+				t.Errorf("ConvertAB() consistent = %v, want %v", conv.Consistent, tt.wantValid)
+			}
+		})
+	}
+}
+
+func TestConvertAB_Consistency(t *testing.T) {
+	property := func(rawB, rawPrice uint32, skewed bool) bool {
+		rateB, price := toDecimal(rawB), toDecimal(rawPrice)
+		rateA := price.Mul(rateB)
+		if skewed {
+			rateA = rateA.Mul(decimal.NewFromInt(1).Add(Tolerance.Mul(decimal.NewFromInt(2))))
+		}
+		f := createTestConverter(nil, map[string]decimal.Decimal{testTokenA: rateA, testTokenB: rateB})
+
+		conv := f.ConvertAB(testTokenA, testTokenB, price)
+		return conv.Consistent == !skewed
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestConvertAB_Unpriceable(t *testing.T) {
+	property := func(rawPrice uint32) bool {
+		f := createTestConverter(nil, nil)
+		conv := f.ConvertAB(testTokenA, testTokenB, toDecimal(rawPrice))
+		return conv.PriceAUSD.IsZero() &&
+			conv.PriceBUSD.IsZero() &&
+			conv.OriginA.Source == SourceNone &&
+			conv.OriginB.Source == SourceNone
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
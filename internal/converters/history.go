@@ -0,0 +1,171 @@
+package converters
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/goccy/go-json"
+	"github.com/kattana-io/tron-blocks-parser/internal/models"
+	"github.com/kattana-io/tron-blocks-parser/internal/tracing"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+/**
+ * Durable price checkpoints, allows to restore prices for any block height
+ * without relying on the previous block being committed a few seconds ago
+ */
+
+// SnapshotInterval - how often (in blocks) LIVE worker persists prices without ttl,
+// PRICES worker persists every block, so its checkpoints chain through each other
+const SnapshotInterval = 100
+
+func snapshotsIndexKey(network string) string {
+	return fmt.Sprintf("parser:prices:%s:snapshots", network)
+}
+
+func snapshotKey(network, number string) string {
+	return fmt.Sprintf("parser:prices:%s:snapshot:%s", network, number)
+}
+
+// shouldSnapshot - decide should we persist prices of current block
+func (f *FiatConverter) shouldSnapshot() bool {
+	switch f.mode {
+	case models.HISTORY, models.RETRY:
+		// parallel workers only read checkpoints built by checkpoint pass
+		return false
+	case models.PRICES:
+		return true
+	default:
+		return f.block.Number.Uint64()%SnapshotInterval == 0
+	}
+}
+
+// writeSnapshot - persist encoded prices and index them by block number
+func (f *FiatConverter) writeSnapshot(ctx context.Context, encoded []byte) (err error) {
+	ctx, span := tracing.Start(ctx, "prices.writeSnapshot")
+	defer func() { tracing.End(span, err) }()
+	number := f.block.Number.String()
+
+	pipe := f.redis.TxPipeline()
+	pipe.Set(ctx, snapshotKey(f.block.Network, number), encoded, 0)
+	pipe.ZAdd(ctx, snapshotsIndexKey(f.block.Network), &redis.Z{
+		Score:  float64(f.block.Number.Uint64()),
+		Member: number,
+	})
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// readNearestSnapshot - restore prices from the closest snapshot before current block
+func (f *FiatConverter) readNearestSnapshot(ctx context.Context) bool {
+	ctx, span := tracing.Start(ctx, "prices.readNearestSnapshot")
+	defer span.End()
+	numbers, err := f.redis.ZRevRangeByScore(ctx, snapshotsIndexKey(f.block.Network), &redis.ZRangeBy{
+		Max:   "(" + strconv.FormatUint(f.block.Number.Uint64(), 10),
+		Min:   "-inf",
+		Count: 1,
+	}).Result()
+	if err != nil || len(numbers) == 0 {
+		return false
+	}
+
+	val, err := f.redis.Get(ctx, snapshotKey(f.block.Network, numbers[0])).Bytes()
+	if err != nil {
+		return false
+	}
+
+	cached := &FiatConverter{}
+	if err := json.Unmarshal(val, &cached); err != nil {
+		f.log.Error(err.Error())
+		return false
+	}
+
+	snapshot, err := strconv.ParseUint(numbers[0], 10, 64)
+	if err != nil {
+		return false
+	}
+	distance := f.block.Number.Uint64() - snapshot
+	if distance > 1 {
+		f.log.Warn("Prices restored from a checkpoint older than previous block",
+			zap.String("block", f.block.Number.String()),
+			zap.Uint64("distance", distance))
+	}
+
+	f.Prices = cached.Prices
+	f.restoreOrigins(cached.Origins, SourceCheckpoint, distance)
+	return true
+}
+
+// repairSnapshots - rebuild checkpoints after current block which were built without it,
+// e.g. while the block waited in failed blocks. Stops at the first missing or unchanged checkpoint
+func (f *FiatConverter) repairSnapshots(ctx context.Context, encoded []byte) (err error) {
+	ctx, span := tracing.Start(ctx, "prices.repairSnapshots")
+	defer func() { tracing.End(span, err) }()
+
+	previous := &FiatConverter{}
+	if err = json.Unmarshal(encoded, previous); err != nil {
+		return err
+	}
+	for number := f.block.Number.Uint64() + 1; ; number++ {
+		key := snapshotKey(f.block.Network, strconv.FormatUint(number, 10))
+		var val []byte
+		val, err = f.redis.Get(ctx, key).Bytes()
+		if err == redis.Nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		stored := &FiatConverter{}
+		if err = json.Unmarshal(val, stored); err != nil {
+			return err
+		}
+		repaired := rebaseSnapshot(previous, stored)
+		if sameSnapshot(repaired, stored) {
+			// following checkpoints were built from this one
+			return nil
+		}
+		if val, err = json.Marshal(repaired); err != nil {
+			return err
+		}
+		if err = f.redis.Set(ctx, key, val, 0).Err(); err != nil {
+			return err
+		}
+		previous = repaired
+	}
+}
+
+// rebaseSnapshot - apply rates defined by the block of stored checkpoint on top of previous checkpoint
+func rebaseSnapshot(previous, stored *FiatConverter) *FiatConverter {
+	rebased := &FiatConverter{
+		Prices:  make(map[string]decimal.Decimal, len(previous.Prices)),
+		Origins: make(map[string]Provenance, len(previous.Prices)),
+	}
+	for token, price := range previous.Prices {
+		rebased.Prices[token] = price
+		rebased.Origins[token] = Provenance{Source: SourceCheckpoint, Pair: previous.Origins[token].Pair, Distance: 1}
+	}
+	for token, origin := range stored.Origins {
+		if origin.Source == SourceBlock {
+			rebased.Prices[token] = stored.Prices[token]
+			rebased.Origins[token] = origin
+		}
+	}
+	return rebased
+}
+
+func sameSnapshot(a, b *FiatConverter) bool {
+	if len(a.Prices) != len(b.Prices) {
+		return false
+	}
+	for token, price := range a.Prices {
+		if other, ok := b.Prices[token]; !ok || !other.Equal(price) || a.Origins[token] != b.Origins[token] {
+			return false
+		}
+	}
+	return true
+}
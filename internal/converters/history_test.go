@@ -0,0 +1,191 @@
+package converters
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/goccy/go-json"
+	commonModels "github.com/kattana-io/models/pkg/storage"
+	"github.com/kattana-io/tron-blocks-parser/internal/models"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+func newHistoryConverter(client *redis.Client, number uint64, mode models.Mode) *FiatConverter {
+	return &FiatConverter{
+		redis:   client,
+		Prices:  make(map[string]decimal.Decimal),
+		Origins: make(map[string]Provenance),
+		block:   &commonModels.Block{Network: "TRON", Number: new(big.Int).SetUint64(number)},
+		mode:    mode,
+		log:     zap.NewNop(),
+	}
+}
+
+func newMiniredis(t *testing.T) *redis.Client {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func Test_shouldSnapshot(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   models.Mode
+		number uint64
+		want   bool
+	}{
+		{name: "Live on interval", mode: models.LIVE, number: 57000000, want: true},
+		{name: "Live between intervals", mode: models.LIVE, number: 57000001, want: false},
+		{name: "Prices on interval", mode: models.PRICES, number: 57000100, want: true},
+		{name: "Prices every block", mode: models.PRICES, number: 57000099, want: true},
+		{name: "History never", mode: models.HISTORY, number: 57000000, want: false},
+		{name: "Retry never", mode: models.RETRY, number: 57000000, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newHistoryConverter(nil, tt.number, tt.mode)
+			if got := f.shouldSnapshot(); got != tt.want {
+				t.Errorf("shouldSnapshot() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_writeSnapshot(t *testing.T) {
+	ctx := context.Background()
+	client := newMiniredis(t)
+	f := newHistoryConverter(client, 57000000, models.PRICES)
+	encoded := []byte(`{"prices":{"TToken":"0.5"}}`)
+
+	if err := f.writeSnapshot(ctx, encoded); err != nil {
+		t.Fatal(err)
+	}
+	got, err := client.Get(ctx, snapshotKey("TRON", "57000000")).Bytes()
+	if err != nil || string(got) != string(encoded) {
+		t.Errorf("snapshot = %s, %v, want %s", got, err, encoded)
+	}
+	if ttl := client.TTL(ctx, snapshotKey("TRON", "57000000")).Val(); ttl != -1 {
+		t.Errorf("snapshot ttl = %v, want none", ttl)
+	}
+	score, err := client.ZScore(ctx, snapshotsIndexKey("TRON"), "57000000").Result()
+	if err != nil || score != 57000000 {
+		t.Errorf("index score = %v, %v, want %v", score, err, 57000000)
+	}
+}
+
+func Test_readNearestSnapshot(t *testing.T) {
+	ctx := context.Background()
+	client := newMiniredis(t)
+	for _, number := range []uint64{57000000, 57000100} {
+		writer := newHistoryConverter(client, number, models.PRICES)
+		writer.Prices["TToken"] = decimal.NewFromInt(int64(number))
+		writer.Origins["TToken"] = Provenance{Source: SourceBlock, Pair: "TPair"}
+		encoded, err := json.Marshal(writer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.writeSnapshot(ctx, encoded); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		number   uint64
+		want     uint64
+		distance uint64
+		found    bool
+	}{
+		{name: "Before the first snapshot", number: 56999999, found: false},
+		{name: "Snapshot of the same block is excluded", number: 57000000, found: false},
+		{name: "Block after snapshot", number: 57000001, want: 57000000, distance: 1, found: true},
+		{name: "Block of the next snapshot reads previous one", number: 57000100, want: 57000000, distance: 100, found: true},
+		{name: "Nearest of several", number: 57000150, want: 57000100, distance: 50, found: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newHistoryConverter(client, tt.number, models.HISTORY)
+			if got := f.readNearestSnapshot(ctx); got != tt.found {
+				t.Fatalf("readNearestSnapshot() = %v, want %v", got, tt.found)
+			}
+			if !tt.found {
+				return
+			}
+			if price := f.Prices["TToken"]; !price.Equal(decimal.NewFromInt(int64(tt.want))) {
+				t.Errorf("restored price = %v, want price of snapshot %v", price, tt.want)
+			}
+			want := Provenance{Source: SourceCheckpoint, Pair: "TPair", Distance: tt.distance}
+			if origin := f.Origins["TToken"]; origin != want {
+				t.Errorf("restored origin = %v, want %v", origin, want)
+			}
+		})
+	}
+}
+
+func Test_repairSnapshots(t *testing.T) {
+	ctx := context.Background()
+	client := newMiniredis(t)
+	price := func(v int64) decimal.Decimal { return decimal.NewFromInt(v) }
+	block := func(pair string) Provenance { return Provenance{Source: SourceBlock, Pair: pair} }
+	carried := func(pair string) Provenance { return Provenance{Source: SourceCheckpoint, Pair: pair, Distance: 1} }
+
+	// 57000001 failed, 57000002 was built from 57000000, 57000004 is stale and not chained with 57000002
+	stored := map[uint64]*FiatConverter{
+		57000000: {Prices: map[string]decimal.Decimal{"TTokenA": price(1)},
+			Origins: map[string]Provenance{"TTokenA": block("TPairA")}},
+		57000002: {Prices: map[string]decimal.Decimal{"TTokenA": price(1), "TTokenB": price(5)},
+			Origins: map[string]Provenance{"TTokenA": carried("TPairA"), "TTokenB": block("TPairB")}},
+		57000004: {Prices: map[string]decimal.Decimal{"TTokenA": price(1)},
+			Origins: map[string]Provenance{"TTokenA": carried("TPairA")}},
+	}
+	for number, snapshot := range stored {
+		encoded, err := json.Marshal(snapshot)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := newHistoryConverter(client, number, models.PRICES).writeSnapshot(ctx, encoded); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	retried := newHistoryConverter(client, 57000001, models.PRICES)
+	retried.Prices["TTokenA"] = price(2)
+	retried.Origins["TTokenA"] = block("TPairA")
+	retried.Commit(ctx)
+
+	tests := []struct {
+		name   string
+		number uint64
+		want   map[string]decimal.Decimal
+	}{
+		{name: "Retried block", number: 57000001, want: map[string]decimal.Decimal{"TTokenA": price(2)}},
+		{name: "Next checkpoint is rebuilt", number: 57000002,
+			want: map[string]decimal.Decimal{"TTokenA": price(2), "TTokenB": price(5)}},
+		{name: "Checkpoint after a gap is kept", number: 57000004, want: map[string]decimal.Decimal{"TTokenA": price(1)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			val, err := client.Get(ctx, snapshotKey("TRON", new(big.Int).SetUint64(tt.number).String())).Bytes()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := &FiatConverter{}
+			if err := json.Unmarshal(val, got); err != nil {
+				t.Fatal(err)
+			}
+			if len(got.Prices) != len(tt.want) {
+				t.Fatalf("prices = %v, want %v", got.Prices, tt.want)
+			}
+			for token, want := range tt.want {
+				if !got.Prices[token].Equal(want) {
+					t.Errorf("price of %s = %v, want %v", token, got.Prices[token], want)
+				}
+			}
+		})
+	}
+}
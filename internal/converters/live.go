@@ -2,6 +2,7 @@ package converters
 
 import (
 	"context"
+	"github.com/kattana-io/tron-blocks-parser/internal/tracing"
 	"github.com/shopspring/decimal"
 )
 
@@ -12,20 +13,23 @@ import (
 const LiveCacheKey = "parser:prices:TRON:live"
 
 // readLastPrices - read last prices from hash of live prices
-func (f *FiatConverter) readLastPrices() {
+func (f *FiatConverter) readLastPrices(ctx context.Context) {
 	f.ratesMutex.Lock()
 	defer f.ratesMutex.Unlock()
-	result := f.redis.HGetAll(context.Background(), LiveCacheKey).Val()
+	ctx, span := tracing.Start(ctx, "prices.readLastPrices")
+	result, err := f.redis.HGetAll(ctx, LiveCacheKey).Result()
+	tracing.End(span, err)
 
 	for key, value := range result {
 		price, err := decimal.NewFromString(value)
 		if err == nil {
 			f.Prices[key] = price
+			f.setOrigin(key, SourceLive, "")
 		}
 	}
 }
 
-func (f *FiatConverter) writeLastPrices() {
+func (f *FiatConverter) writeLastPrices(ctx context.Context) {
 	f.ratesMutex.Lock()
 	defer f.ratesMutex.Unlock()
 
@@ -33,5 +37,6 @@ func (f *FiatConverter) writeLastPrices() {
 	for key, value := range f.Prices {
 		result[key] = value.String()
 	}
-	f.redis.HSet(context.Background(), LiveCacheKey, result)
+	ctx, span := tracing.Start(ctx, "prices.writeLastPrices")
+	tracing.End(span, f.redis.HSet(ctx, LiveCacheKey, result).Err())
 }
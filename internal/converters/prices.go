@@ -0,0 +1,90 @@
+package converters
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+/**
+ * Per block aggregation of token prices, allows consumers to build candles without replaying swaps
+ */
+
+// TokenPrice - open, high, low, close and volume weighted average USD price of token within a block
+type TokenPrice struct {
+	Token     string          `json:"token"`
+	Open      decimal.Decimal `json:"open"`
+	High      decimal.Decimal `json:"high"`
+	Low       decimal.Decimal `json:"low"`
+	Close     decimal.Decimal `json:"close"`
+	VWAP      decimal.Decimal `json:"vwap"`
+	Volume    decimal.Decimal `json:"volume"`
+	VolumeUSD decimal.Decimal `json:"volume_usd"`
+	Trades    int             `json:"trades"`
+}
+
+type blockPrices struct {
+	lock   sync.Mutex
+	tokens map[string]*TokenPrice
+}
+
+func newBlockPrices() *blockPrices {
+	return &blockPrices{
+		tokens: make(map[string]*TokenPrice),
+	}
+}
+
+// observe - register trade of amount tokens at priceUSD
+func (b *blockPrices) observe(token string, priceUSD, amount decimal.Decimal) {
+	if priceUSD.IsZero() || amount.IsZero() {
+		return
+	}
+	amount = amount.Abs()
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	item, ok := b.tokens[token]
+	if !ok {
+		item = &TokenPrice{
+			Token: token,
+			Open:  priceUSD,
+			High:  priceUSD,
+			Low:   priceUSD,
+		}
+		b.tokens[token] = item
+	}
+	item.High = decimal.Max(item.High, priceUSD)
+	item.Low = decimal.Min(item.Low, priceUSD)
+	item.Close = priceUSD
+	item.Volume = item.Volume.Add(amount)
+	item.VolumeUSD = item.VolumeUSD.Add(amount.Mul(priceUSD))
+	item.VWAP = item.VolumeUSD.Div(item.Volume)
+	item.Trades++
+}
+
+// list - return aggregated prices sorted by token address
+func (b *blockPrices) list() []*TokenPrice {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	result := make([]*TokenPrice, 0, len(b.tokens))
+	for _, item := range b.tokens {
+		result = append(result, item)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Token < result[j].Token
+	})
+	return result
+}
+
+// Observe - register trade of token for per block price aggregation
+func (f *FiatConverter) Observe(token string, priceUSD, amount decimal.Decimal) {
+	f.blockPrices.observe(token, priceUSD, amount)
+}
+
+// BlockPrices - aggregated prices of tokens traded in current block
+func (f *FiatConverter) BlockPrices() []*TokenPrice {
+	return f.blockPrices.list()
+}
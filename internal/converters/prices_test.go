@@ -0,0 +1,59 @@
+package converters
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func Test_blockPrices_observe(t *testing.T) {
+	type trade struct {
+		price  string
+		amount string
+	}
+	tests := []struct {
+		name   string
+		trades []trade
+		want   TokenPrice
+	}{
+		{
+			name:   "Single trade",
+			trades: []trade{{price: "2", amount: "10"}},
+			want: TokenPrice{
+				Open: decimal.RequireFromString("2"), High: decimal.RequireFromString("2"),
+				Low: decimal.RequireFromString("2"), Close: decimal.RequireFromString("2"),
+				VWAP: decimal.RequireFromString("2"), Volume: decimal.RequireFromString("10"),
+				VolumeUSD: decimal.RequireFromString("20"), Trades: 1,
+			},
+		},
+		{
+			name:   "Multiple trades, zero price skipped",
+			trades: []trade{{price: "2", amount: "10"}, {price: "4", amount: "30"}, {price: "0", amount: "5"}, {price: "1", amount: "-10"}},
+			want: TokenPrice{
+				Open: decimal.RequireFromString("2"), High: decimal.RequireFromString("4"),
+				Low: decimal.RequireFromString("1"), Close: decimal.RequireFromString("1"),
+				VWAP: decimal.RequireFromString("3"), Volume: decimal.RequireFromString("50"),
+				VolumeUSD: decimal.RequireFromString("150"), Trades: 3,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newBlockPrices()
+			for _, tr := range tt.trades {
+				b.observe("T", decimal.RequireFromString(tr.price), decimal.RequireFromString(tr.amount))
+			}
+			list := b.list()
+			if len(list) != 1 {
+				t.Fatalf("list() len = %v, want 1", len(list))
+			}
+			got := list[0]
+			if !got.Open.Equal(tt.want.Open) || !got.High.Equal(tt.want.High) ||
+				!got.Low.Equal(tt.want.Low) || !got.Close.Equal(tt.want.Close) ||
+				!got.VWAP.Equal(tt.want.VWAP) || !got.Volume.Equal(tt.want.Volume) ||
+				!got.VolumeUSD.Equal(tt.want.VolumeUSD) || got.Trades != tt.want.Trades {
+				t.Errorf("observe() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package converters
+
+/**
+ * Origin of USD prices, helps to explain odd USD values downstream
+ */
+
+// Source - compact code of where USD price came from
+type Source uint8
+
+const (
+	SourceNone       Source = iota // could not be priced, value is zero
+	SourceStable                   // stablecoin leg of the pair
+	SourceBlock                    // rate updated by an event of current block
+	SourcePrevious                 // rate carried from previous block cache
+	SourceCheckpoint               // rate restored from the nearest price checkpoint
+	SourceLive                     // rate read from the hash of live prices
+)
+
+// Provenance - source of the rate and the pair which defined it
+// Pair is empty when pair is unknown or conversion used a stablecoin leg of the event itself,
+// Distance - blocks between current block and the checkpoint rate was restored from, zero for other sources
+type Provenance struct {
+	Source   Source `json:"source"`
+	Pair     string `json:"pair,omitempty"`
+	Distance uint64 `json:"distance,omitempty"`
+}
+
+// setOrigin - should be called under rates lock
+func (f *FiatConverter) setOrigin(token string, source Source, pair string) {
+	f.Origins[token] = Provenance{
+		Source: source,
+		Pair:   pair,
+	}
+}
+
+// restoreOrigins - mark all restored rates with source and distance, keeping pairs which defined them
+func (f *FiatConverter) restoreOrigins(cached map[string]Provenance, source Source, distance uint64) {
+	origins := make(map[string]Provenance, len(f.Prices))
+	for token := range f.Prices {
+		origins[token] = Provenance{
+			Source:   source,
+			Pair:     cached[token].Pair,
+			Distance: distance,
+		}
+	}
+	f.Origins = origins
+}
+
+func (f *FiatConverter) getOrigin(token string) Provenance {
+	if f.isTokenStable(token) {
+		return Provenance{Source: SourceStable}
+	}
+
+	f.ratesMutex.RLock()
+	defer f.ratesMutex.RUnlock()
+	if val, ok := f.Prices[token]; !ok || val.IsZero() {
+		return Provenance{}
+	}
+	return f.Origins[token]
+}
@@ -0,0 +1,27 @@
+package encoding
+
+import (
+	"fmt"
+
+	"github.com/kattana-io/tron-blocks-parser/internal/models"
+)
+
+// Encoder - serializes published payloads, name is advertised in message headers
+type Encoder interface {
+	Name() models.Encoding
+	Encode(v any) ([]byte, error)
+}
+
+// New - create encoder by name
+func New(name models.Encoding) (Encoder, error) {
+	switch name {
+	case models.MsgPack:
+		return MsgPack{}, nil
+	case models.JSON:
+		return JSON{}, nil
+	case models.Protobuf:
+		return Protobuf{}, nil
+	default:
+		return nil, fmt.Errorf("unknown encoding %q", name)
+	}
+}
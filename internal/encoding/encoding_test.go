@@ -0,0 +1,79 @@
+package encoding
+
+import (
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/kattana-io/tron-blocks-parser/internal/models"
+	"github.com/kattana-io/tron-blocks-parser/pkg/pb"
+	"github.com/shopspring/decimal"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+type testPayload struct {
+	Number uint64          `json:"number"`
+	Price  decimal.Decimal `json:"price"`
+}
+
+func (p testPayload) ToProto() proto.Message {
+	return &pb.TokenPrice{Trades: int64(p.Number), Close: p.Price.String()}
+}
+
+func TestEncoders(t *testing.T) {
+	payload := testPayload{Number: 42, Price: decimal.RequireFromString("0.0731")}
+	tests := []struct {
+		name   models.Encoding
+		decode func(b []byte) (string, error)
+	}{
+		{name: models.MsgPack, decode: func(b []byte) (string, error) {
+			got := testPayload{}
+			err := msgpack.Unmarshal(b, &got)
+			return got.Price.String(), err
+		}},
+		{name: models.JSON, decode: func(b []byte) (string, error) {
+			got := testPayload{}
+			err := json.Unmarshal(b, &got)
+			return got.Price.String(), err
+		}},
+		{name: models.Protobuf, decode: func(b []byte) (string, error) {
+			got := &pb.TokenPrice{}
+			err := proto.Unmarshal(b, got)
+			return got.GetClose(), err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.name), func(t *testing.T) {
+			enc, err := New(tt.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if enc.Name() != tt.name {
+				t.Errorf("Name() = %v, want %v", enc.Name(), tt.name)
+			}
+			b, err := enc.Encode(payload)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := tt.decode(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != "0.0731" {
+				t.Errorf("decoded price = %v, want %v", got, "0.0731")
+			}
+		})
+	}
+}
+
+func TestProtobuf_WithoutMessage(t *testing.T) {
+	if _, err := (Protobuf{}).Encode(struct{}{}); err == nil {
+		t.Errorf("Encode() expected error for payload without protobuf message")
+	}
+}
+
+func TestNew_Unknown(t *testing.T) {
+	if _, err := New("xml"); err == nil {
+		t.Errorf("New() expected error for unknown encoding")
+	}
+}
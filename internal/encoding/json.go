@@ -0,0 +1,17 @@
+package encoding
+
+import (
+	"github.com/goccy/go-json"
+	"github.com/kattana-io/tron-blocks-parser/internal/models"
+)
+
+// JSON - decimals are encoded as strings
+type JSON struct{}
+
+func (JSON) Name() models.Encoding {
+	return models.JSON
+}
+
+func (JSON) Encode(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
@@ -0,0 +1,17 @@
+package encoding
+
+import (
+	"github.com/kattana-io/tron-blocks-parser/internal/models"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// MsgPack - default encoding of parsed blocks
+type MsgPack struct{}
+
+func (MsgPack) Name() models.Encoding {
+	return models.MsgPack
+}
+
+func (MsgPack) Encode(v any) ([]byte, error) {
+	return msgpack.Marshal(v)
+}
@@ -0,0 +1,31 @@
+package encoding
+
+import (
+	"fmt"
+
+	"github.com/kattana-io/tron-blocks-parser/internal/models"
+	"google.golang.org/protobuf/proto"
+)
+
+// ProtoMarshaler - payload which has a message in pkg/pb
+type ProtoMarshaler interface {
+	ToProto() proto.Message
+}
+
+/**
+ * Protobuf - payload is converted to its message of pkg/pb, decimals are strings,
+ * so amounts keep full precision unlike JSON numbers
+ */
+type Protobuf struct{}
+
+func (Protobuf) Name() models.Encoding {
+	return models.Protobuf
+}
+
+func (Protobuf) Encode(v any) ([]byte, error) {
+	m, ok := v.(ProtoMarshaler)
+	if !ok {
+		return nil, fmt.Errorf("%T has no protobuf message", v)
+	}
+	return proto.Marshal(m.ToProto())
+}
@@ -33,6 +33,11 @@ func NewQuotesFile() *QuotesFile {
 	}
 }
 
+// Loaded - quotes.json was read on start
+func (q *QuotesFile) Loaded() bool {
+	return q != nil
+}
+
 func (q *QuotesFile) Get() []models.QuotePair {
 	q.lock.Lock()
 	defer q.lock.Unlock()
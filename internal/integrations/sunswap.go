@@ -47,6 +47,11 @@ func (t *SunswapProvider) GetToken(address string) (models.Token, bool) {
 	return models.Token{}, false
 }
 
+// Loaded - sunswap.json was read on start
+func (t *SunswapProvider) Loaded() bool {
+	return t.ok
+}
+
 func NewSunswapProvider() *SunswapProvider {
 	list, err := loadSunswapMapping()
 	ok := err == nil
@@ -64,6 +64,11 @@ func createDecimalsList(resp map[string]Token) *sync.Map {
 	return &smp
 }
 
+// Loaded - tokens.json was read on start
+func (t *TokenListsProvider) Loaded() bool {
+	return t.ok
+}
+
 // GetDecimals - Fetch decimals from sync map
 func (t *TokenListsProvider) GetDecimals(address *tronApi.Address) (int32, bool) {
 	val, ok := t.decimals.Load(address.ToBase58())
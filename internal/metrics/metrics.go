@@ -0,0 +1,126 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+/**
+ * Prometheus metrics of parser, served by runway on /metrics
+ */
+
+const namespace = "tron_parser"
+
+var (
+	BlocksParsed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "blocks_parsed_total",
+		Help:      "Blocks parsed and published",
+	}, []string{"mode"})
+
+	BlocksFailed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "blocks_failed_total",
+		Help:      "Blocks returned to failed blocks, stage is parse or publish",
+	}, []string{"mode", "stage"})
+
+	ParseDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "parse_duration_seconds",
+		Help:      "Time to parse block",
+		Buckets:   prometheus.ExponentialBuckets(0.05, 2, 12),
+	})
+
+	LogsProcessed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "logs_total",
+		Help:      "Transaction logs by event type",
+	}, []string{"event"})
+
+	PairCache = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pair_cache_total",
+		Help:      "Pair cache lookups, result is hit or miss",
+	}, []string{"result"})
+
+	NodeDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "node_request_duration_seconds",
+		Help:      "Latency of node requests by method",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	NodeErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "node_request_errors_total",
+		Help:      "Failed node requests by method",
+	}, []string{"method"})
+
+	NodeFailovers = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "node_failovers_total",
+		Help:      "Node requests retried on the next endpoint of pool by method",
+	}, []string{"method"})
+
+	NodeRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "node_retries_total",
+		Help:      "Node requests repeated with backoff after every endpoint failed, by method",
+	}, []string{"method"})
+
+	NodeEndpointScore = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "node_endpoint_score",
+		Help:      "Health score of node endpoint, share of recent successful calls",
+	}, []string{"endpoint"})
+
+	PublishDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "kafka_publish_duration_seconds",
+		Help:      "Latency of Kafka writes by topic",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"topic"})
+
+	PublishErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "kafka_publish_errors_total",
+		Help:      "Failed Kafka writes by topic",
+	}, []string{"topic"})
+
+	LastBlock = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "last_block",
+		Help:      "Number of the last parsed block",
+	}, []string{"network", "mode"})
+
+	BlockLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "block_lag_seconds",
+		Help:      "Time between block timestamp and its parsing",
+	}, []string{"network", "mode"})
+)
+
+// ObserveNode - record latency and error of node request started at start
+func ObserveNode(method string, start time.Time, err error) {
+	NodeDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	if err != nil {
+		NodeErrors.WithLabelValues(method).Inc()
+	}
+}
+
+// ObservePublish - record latency and error of Kafka write started at start
+func ObservePublish(topic string, start time.Time, err error) {
+	PublishDuration.WithLabelValues(topic).Observe(time.Since(start).Seconds())
+	if err != nil {
+		PublishErrors.WithLabelValues(topic).Inc()
+	}
+}
+
+// ObserveBlock - record last parsed block, timestamp is in milliseconds
+func ObserveBlock(network, mode string, number uint64, timestamp uint64) {
+	LastBlock.WithLabelValues(network, mode).Set(float64(number))
+	lag := time.Since(time.UnixMilli(int64(timestamp)))
+	BlockLag.WithLabelValues(network, mode).Set(lag.Seconds())
+}
@@ -0,0 +1,10 @@
+package models
+
+// Compression - codec of produced Kafka batches
+type Compression string
+
+const (
+	CompressionNone   Compression = "none"
+	CompressionSnappy Compression = "snappy"
+	CompressionZstd   Compression = "zstd"
+)
@@ -0,0 +1,11 @@
+package models
+
+// Encoding - format of published payloads
+type Encoding string
+
+const (
+	MsgPack Encoding = "msgpack"
+	JSON    Encoding = "json"
+	// Protobuf - google.protobuf.Struct with the same field names as JSON
+	Protobuf Encoding = "protobuf"
+)
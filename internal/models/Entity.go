@@ -0,0 +1,12 @@
+package models
+
+// Entity - kind of parsed events, published to its own topic on fan-out
+type Entity string
+
+const (
+	PairSwaps   Entity = "pair_swaps"
+	DirectSwaps Entity = "direct_swaps"
+	Liquidities Entity = "liquidity_events"
+	Pairs       Entity = "new_pairs"
+	Transfers   Entity = "transfer_events"
+)
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/kattana-io/models/pkg/storage"
+)
+
+// FailedBlockSchemaVersion - version of FailedBlock envelope
+const FailedBlockSchemaVersion = 1
+
+// FailedBlock - envelope of block which could not be parsed or published
+type FailedBlock struct {
+	Block         storage.Block `json:"block"`
+	Reason        string        `json:"reason"`
+	Attempt       int           `json:"attempt"`
+	ParserVersion string        `json:"parser_version"`
+	Timestamp     int64         `json:"timestamp"`
+	// Mode - mode of worker which failed, PRICES blocks are retried as price checkpoints
+	Mode Mode `json:"mode,omitempty"`
+}
+
+// RetryAt - time of the next attempt, delay doubles with every attempt up to limit
+func (f *FailedBlock) RetryAt(base, limit time.Duration) time.Time {
+	delay := base
+	for i := 1; i < f.Attempt && delay < limit; i++ {
+		delay *= 2
+	}
+	if delay > limit {
+		delay = limit
+	}
+	return time.Unix(f.Timestamp, 0).Add(delay)
+}
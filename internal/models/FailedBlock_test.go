@@ -0,0 +1,27 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFailedBlock_RetryAt(t *testing.T) {
+	tests := []struct {
+		name    string
+		attempt int
+		want    time.Duration
+	}{
+		{name: "First attempt", attempt: 1, want: time.Minute},
+		{name: "Third attempt", attempt: 3, want: 4 * time.Minute},
+		{name: "Limited", attempt: 10, want: time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &FailedBlock{Attempt: tt.attempt, Timestamp: 1700000000}
+			got := f.RetryAt(time.Minute, time.Hour).Sub(time.Unix(f.Timestamp, 0))
+			if got != tt.want {
+				t.Errorf("RetryAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package models
+
+// KeyStrategy - how published messages are keyed and partitioned
+type KeyStrategy string
+
+const (
+	// KeyByBlock - key is network and block number, re-published block lands on the same partition
+	KeyByBlock KeyStrategy = "block"
+	// KeyByNetwork - key is network, all blocks of network are ordered in one partition
+	KeyByNetwork KeyStrategy = "network"
+	// KeyNone - messages without key, spread over partitions round-robin
+	KeyNone KeyStrategy = "none"
+)
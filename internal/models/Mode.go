@@ -5,4 +5,8 @@ type Mode string
 const (
 	LIVE    Mode = "LIVE"
 	HISTORY Mode = "HISTORY"
+	// PRICES - first pass of history backfill, builds price checkpoints sequentially
+	PRICES Mode = "PRICES"
+	// RETRY - re-parse blocks from failed_blocks with backoff
+	RETRY Mode = "RETRY"
 )
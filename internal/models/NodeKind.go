@@ -0,0 +1,11 @@
+package models
+
+// NodeKind - type of node endpoint in pool
+type NodeKind string
+
+const (
+	// FullNode - serves every call, constant calls unless node reports it doesn't support them
+	FullNode NodeKind = "full"
+	// SolidityNode - serves blocks and transaction infos, never constant calls
+	SolidityNode NodeKind = "solidity"
+)
@@ -5,4 +5,12 @@ type Topics string
 const (
 	TronLive    Topics = "tron_live_blocks"
 	TronHistory Topics = "tron_history_blocks"
+	TronPrices  Topics = "tron_prices_blocks"
+	// FailedBlocks - blocks which could not be parsed or published
+	FailedBlocks Topics = "failed_blocks"
+	// DeadBlocks - failed blocks which exceeded max attempts
+	DeadBlocks Topics = "dead_blocks"
+	// Parsed - output topics
+	Parsed  Topics = "parser.sys.parsed"
+	Holders Topics = "holders_blocks"
 )
@@ -0,0 +1,10 @@
+package models
+
+// TracingExporter - destination of OpenTelemetry spans
+type TracingExporter string
+
+const (
+	TracingNone   TracingExporter = "none"
+	TracingStdout TracingExporter = "stdout"
+	TracingOTLP   TracingExporter = "otlp"
+)
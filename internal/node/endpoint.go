@@ -0,0 +1,187 @@
+package node
+
+import (
+	"errors"
+	"math"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kattana-io/tron-blocks-parser/internal/metrics"
+	"github.com/kattana-io/tron-blocks-parser/internal/models"
+	tronApi "github.com/kattana-io/tron-objects-api/pkg/api"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// scoreWeight - weight of the last call in health score
+	scoreWeight = 0.2
+	// maxFailures - consecutive failures after which endpoint cools down
+	maxFailures = 3
+	// maxCooldownFactor - cooldown doubles with further failures up to this factor
+	maxCooldownFactor = 16
+)
+
+// endpoint - node of pool with its limiter and health
+type endpoint struct {
+	// name - host of node, used in logs and metrics instead of url
+	name    string
+	url     string
+	kind    models.NodeKind
+	api     *tronApi.API
+	limiter *rate.Limiter
+
+	mu sync.Mutex
+	// score - moving share of successful calls, 1 is healthy, exposed as metric
+	score    float64
+	failures int
+	until    time.Time
+	// noConstant - node reported that it doesn't support constant calls
+	noConstant bool
+}
+
+func newEndpoint(name, url string, kind models.NodeKind, api *tronApi.API, rps float64) *endpoint {
+	limit, burst := rate.Inf, 1
+	if rps > 0 {
+		limit, burst = rate.Limit(rps), int(math.Ceil(rps))
+	}
+	e := &endpoint{
+		name:    name,
+		url:     url,
+		kind:    kind,
+		api:     api,
+		limiter: rate.NewLimiter(limit, burst),
+		score:   1,
+	}
+	metrics.NodeEndpointScore.WithLabelValues(name).Set(e.score)
+	return e
+}
+
+// endpointName - host of url, credentials and path are not exposed
+func endpointName(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return raw
+	}
+	return u.Host
+}
+
+// supports - solidity nodes and nodes which rejected constant calls serve only reads
+func (e *endpoint) supports(kind Kind) bool {
+	if kind != Constant {
+		return true
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.kind != models.SolidityNode && !e.noConstant
+}
+
+// health - score and whether endpoint is cooling down at now
+func (e *endpoint) health(now time.Time) (float64, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.score, now.Before(e.until)
+}
+
+func (e *endpoint) cooldownUntil() time.Time {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.until
+}
+
+func (e *endpoint) success() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.failures = 0
+	e.until = time.Time{}
+	e.score = e.score*(1-scoreWeight) + scoreWeight
+	metrics.NodeEndpointScore.WithLabelValues(e.name).Set(e.score)
+}
+
+// failure - lower score, endpoint cools down after maxFailures in a row or immediately when rate limited
+func (e *endpoint) failure(now time.Time, cooldown time.Duration, limited bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.failures++
+	e.score *= 1 - scoreWeight
+	metrics.NodeEndpointScore.WithLabelValues(e.name).Set(e.score)
+
+	switch {
+	case limited:
+		e.until = now.Add(cooldown)
+	case e.failures >= maxFailures:
+		factor := maxCooldownFactor
+		if shift := e.failures - maxFailures; shift < 4 {
+			factor = 1 << shift
+		}
+		e.until = now.Add(cooldown * time.Duration(factor))
+	}
+}
+
+func (e *endpoint) rejectConstant() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.noConstant = true
+}
+
+// outcome - how failed call affects endpoint
+type outcome int
+
+const (
+	// nodeFailure - node is down or overloaded, counts against its health
+	nodeFailure outcome = iota
+	// answered - node is healthy, but block isn't there yet. Reverts are permanent and never classified
+	answered
+	// unsupported - node doesn't serve constant calls
+	unsupported
+)
+
+// behindError - node is healthy but doesn't have requested data yet
+type behindError struct {
+	err error
+}
+
+func (e *behindError) Error() string { return e.err.Error() }
+
+func (e *behindError) Unwrap() error { return e.err }
+
+// Behind - mark error of node which lags behind, e.g. empty block at the head. Next endpoint is tried
+// without lowering health of this one
+func Behind(err error) error {
+	return &behindError{err: err}
+}
+
+func classify(kind Kind, err error) outcome {
+	var behind *behindError
+	switch {
+	case errors.As(err, &behind):
+		return answered
+	case kind == Constant && isUnsupportedConstant(err):
+		return unsupported
+	default:
+		return nodeFailure
+	}
+}
+
+// isReverted - constant call of contract without such method or with failed require
+func isReverted(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "revert") || strings.Contains(msg, "contract_validate_error") ||
+		strings.Contains(msg, "contract validate error")
+}
+
+// isUnsupportedConstant - full node without vm.supportConstant rejects triggerconstantcontract
+func isUnsupportedConstant(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "doesnt support constant") || strings.Contains(msg, "does not support constant")
+}
+
+// rateLimited - status of response, bare 429 may be a part of number, hash or address
+var rateLimited = regexp.MustCompile(`(?i)too many requests|status(?: code)?:? 429\b`)
+
+// isRateLimited - TronGrid and proxies answer 429 when limit of key is exceeded
+func isRateLimited(err error) bool {
+	return rateLimited.MatchString(err.Error())
+}
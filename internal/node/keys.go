@@ -0,0 +1,43 @@
+package node
+
+import (
+	"net/http"
+	"net/url"
+	"sync/atomic"
+)
+
+// KeyHeader - header of TronGrid API key
+const KeyHeader = "TRON-PRO-API-KEY"
+
+// KeyTransport - attach TronGrid API keys in round-robin to requests of TronGrid host
+type KeyTransport struct {
+	base http.RoundTripper
+	host string
+	keys []string
+	next atomic.Uint64
+}
+
+// NewKeyTransport - node api clients send requests with default http client,
+// so transport wraps http.DefaultTransport to add keys without changing them
+func NewKeyTransport(base http.RoundTripper, keys []string) *KeyTransport {
+	host := TrongridURL
+	if u, err := url.Parse(TrongridURL); err == nil {
+		host = u.Host
+	}
+	return &KeyTransport{
+		base: base,
+		host: host,
+		keys: keys,
+	}
+}
+
+func (t *KeyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(t.keys) == 0 || req.URL.Host != t.host || req.Header.Get(KeyHeader) != "" {
+		return t.base.RoundTrip(req)
+	}
+	key := t.keys[(t.next.Add(1)-1)%uint64(len(t.keys))]
+	// RoundTripper should not modify request
+	req = req.Clone(req.Context())
+	req.Header.Set(KeyHeader, key)
+	return t.base.RoundTrip(req)
+}
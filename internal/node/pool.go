@@ -0,0 +1,189 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kattana-io/tron-blocks-parser/internal/config"
+	"github.com/kattana-io/tron-blocks-parser/internal/metrics"
+	"github.com/kattana-io/tron-blocks-parser/internal/models"
+	tronApi "github.com/kattana-io/tron-objects-api/pkg/api"
+	"github.com/kattana-io/tron-objects-api/pkg/url"
+	"go.uber.org/zap"
+)
+
+/**
+ * Pool of node endpoints shared by parsers: every call goes to the first endpoint of config which supports it
+ * and isn't cooling down, waits for its rate limiter and fails over to the next one on error. TronGrid is the last endpoint.
+ * When every endpoint failed, the pass is repeated with backoff until the error is permanent or attempts are over
+ */
+
+// Kind - kind of node call, defines which endpoints can serve it
+type Kind int
+
+const (
+	// Read - blocks and transaction infos
+	Read Kind = iota
+	// Constant - triggerconstantcontract, served only by full nodes which support it
+	Constant
+)
+
+const (
+	trongridName = "trongrid"
+	TrongridURL  = "https://api.trongrid.io"
+)
+
+// ErrNoEndpoint - no endpoint of pool can serve the call
+var ErrNoEndpoint = errors.New("no node endpoint supports the call")
+
+type Pool struct {
+	mu        sync.RWMutex
+	endpoints []*endpoint
+	cooldown  time.Duration
+	retry     config.Retry
+	log       *zap.Logger
+	now       func() time.Time
+}
+
+// New - endpoints in order of config, full node url if it isn't listed and TronGrid as the last resort
+func New(cfg config.Node, log *zap.Logger) *Pool {
+	p := &Pool{
+		cooldown: cfg.Cooldown,
+		retry:    cfg.Retry,
+		log:      log,
+		now:      time.Now,
+	}
+	for _, e := range cfg.Endpoints {
+		kind := e.Kind
+		if kind == "" {
+			kind = models.FullNode
+		}
+		p.add(e.URL, kind, e.RateLimit)
+	}
+	if cfg.FullNodeURL != "" {
+		p.Add(cfg.FullNodeURL)
+	}
+	keys := len(cfg.TrongridKeys)
+	if keys == 0 {
+		keys = 1
+	}
+	trongrid := tronApi.NewAPI("", log, url.NewTrongridURLProvider())
+	p.endpoints = append(p.endpoints, newEndpoint(trongridName, TrongridURL, models.FullNode, trongrid, cfg.TrongridRateLimit*float64(keys)))
+	return p
+}
+
+// Add - register unlimited full node before TronGrid, blocks may point to node which is not configured
+func (p *Pool) Add(nodeURL string) {
+	if nodeURL == "" {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, e := range p.endpoints {
+		if e.url == nodeURL {
+			return
+		}
+	}
+	p.add(nodeURL, models.FullNode, 0)
+	// keep TronGrid last
+	if n := len(p.endpoints); n > 1 && p.endpoints[n-2].name == trongridName {
+		p.endpoints[n-2], p.endpoints[n-1] = p.endpoints[n-1], p.endpoints[n-2]
+	}
+}
+
+func (p *Pool) add(nodeURL string, kind models.NodeKind, rps float64) {
+	api := tronApi.NewAPI(nodeURL, p.log, url.NewNodeURLProvider(nodeURL))
+	p.endpoints = append(p.endpoints, newEndpoint(endpointName(nodeURL), nodeURL, kind, api, rps))
+}
+
+// Call - run call on endpoints until one succeeds, every endpoint is tried at most once per attempt
+func (p *Pool) Call(ctx context.Context, kind Kind, method string, call func(api *tronApi.API) error) error {
+	return p.withRetry(ctx, method, func() error {
+		return p.do(ctx, kind, method, func(e *endpoint) error {
+			return call(e.api)
+		})
+	})
+}
+
+// do - single pass over endpoints, permanent error stops it since other nodes return the same.
+// Pass is permanent when no endpoint failed with retryable error, e.g. every node rejected constant call
+func (p *Pool) do(ctx context.Context, kind Kind, method string, call func(e *endpoint) error) error {
+	tried := make(map[*endpoint]bool)
+	var errs []error
+	retryable := false
+	for {
+		e := p.pick(kind, tried)
+		if e == nil {
+			break
+		}
+		tried[e] = true
+		if err := e.limiter.Wait(ctx); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+
+		start := time.Now()
+		err := call(e)
+		metrics.ObserveNode(method, start, err)
+		if err == nil {
+			e.success()
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", e.name, err))
+
+		// reverts and 4xx are the same on every node, so they are neither classified nor failed over
+		if !Retryable(err) {
+			return Permanent(errors.Join(errs...))
+		}
+		switch classify(kind, err) {
+		case unsupported:
+			// not a failure of node, it is just never asked for constant calls again
+			e.rejectConstant()
+		case nodeFailure:
+			retryable = true
+			e.failure(p.now(), p.cooldown, isRateLimited(err))
+		default:
+			retryable = true
+		}
+		if ctx.Err() != nil {
+			return errors.Join(append(errs, ctx.Err())...)
+		}
+		metrics.NodeFailovers.WithLabelValues(method).Inc()
+		p.log.Warn("Node call failed, trying next endpoint",
+			zap.String("method", method),
+			zap.String("endpoint", e.name),
+			zap.Error(err))
+	}
+	switch {
+	case len(errs) == 0:
+		return ErrNoEndpoint
+	case !retryable:
+		return Permanent(errors.Join(errs...))
+	}
+	return errors.Join(errs...)
+}
+
+// pick - first endpoint in config order which supports kind, wasn't tried and isn't cooling down.
+// When every endpoint cools down, the one which recovers first is used
+func (p *Pool) pick(kind Kind, tried map[*endpoint]bool) *endpoint {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	now := p.now()
+	var cooling *endpoint
+	var coolingUntil time.Time
+	for _, e := range p.endpoints {
+		if tried[e] || !e.supports(kind) {
+			continue
+		}
+		until := e.cooldownUntil()
+		if !now.Before(until) {
+			return e
+		}
+		if cooling == nil || until.Before(coolingUntil) {
+			cooling, coolingUntil = e, until
+		}
+	}
+	return cooling
+}
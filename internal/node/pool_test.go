@@ -0,0 +1,241 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kattana-io/tron-blocks-parser/internal/config"
+	"github.com/kattana-io/tron-blocks-parser/internal/models"
+	"go.uber.org/zap"
+)
+
+func createPool() *Pool {
+	return New(config.Node{
+		Endpoints: []config.Endpoint{
+			{URL: "http://full:8090"},
+			{URL: "http://solidity:8091", Kind: models.SolidityNode},
+		},
+		FullNodeURL:       "http://backup:8090",
+		TrongridRateLimit: 1000,
+		Cooldown:          time.Minute,
+	}, zap.NewNop())
+}
+
+func TestPool_do(t *testing.T) {
+	errNode := errors.New("connection refused")
+	tests := []struct {
+		name string
+		kind Kind
+		// errs - result of call by endpoint, missing endpoint succeeds
+		errs    map[string]error
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "First endpoint",
+			kind: Read,
+			want: []string{"full:8090"},
+		},
+		{
+			name: "Fail over to next endpoint",
+			kind: Read,
+			errs: map[string]error{"full:8090": errNode},
+			want: []string{"full:8090", "solidity:8091"},
+		},
+		{
+			name: "Constant call skips solidity node",
+			kind: Constant,
+			errs: map[string]error{"full:8090": errNode},
+			want: []string{"full:8090", "backup:8090"},
+		},
+		{
+			name: "Node without constant support",
+			kind: Constant,
+			errs: map[string]error{"full:8090": errors.New("this node doesnt support constant")},
+			want: []string{"full:8090", "backup:8090"},
+		},
+		{
+			name:    "Permanent error stops failover",
+			kind:    Constant,
+			errs:    map[string]error{"full:8090": Permanent(errors.New("not a token"))},
+			want:    []string{"full:8090"},
+			wantErr: true,
+		},
+		{
+			name:    "Every endpoint failed",
+			kind:    Constant,
+			errs:    map[string]error{"full:8090": errNode, "backup:8090": errNode, trongridName: errNode},
+			want:    []string{"full:8090", "backup:8090", trongridName},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := createPool()
+			var got []string
+			err := p.do(context.Background(), tt.kind, "test", func(e *endpoint) error {
+				got = append(got, e.name)
+				return tt.errs[e.name]
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("do() endpoints = %v, want %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("do() endpoints = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestPool_health(t *testing.T) {
+	p := createPool()
+	ctx := context.Background()
+	first := func(kind Kind, errs map[string]error) string {
+		var name string
+		_ = p.do(ctx, kind, "test", func(e *endpoint) error {
+			if name == "" {
+				name = e.name
+			}
+			return errs[e.name]
+		})
+		return name
+	}
+
+	// constant support is remembered
+	unsupported := map[string]error{"full:8090": errors.New("this node doesnt support constant")}
+	first(Constant, unsupported)
+	if got := first(Constant, nil); got != "backup:8090" {
+		t.Errorf("constant call after rejection went to %v, want backup:8090", got)
+	}
+	if got := first(Read, nil); got != "full:8090" {
+		t.Errorf("read after constant rejection went to %v, want full:8090", got)
+	}
+
+	// lagging node is an answer of healthy node, reverted contract call is permanent, neither lowers health
+	for i := 0; i < maxFailures; i++ {
+		first(Read, map[string]error{"full:8090": Behind(errors.New("empty response"))})
+		first(Constant, map[string]error{"backup:8090": errors.New("REVERT opcode executed")})
+	}
+	if got := first(Read, nil); got != "full:8090" {
+		t.Errorf("read after empty blocks went to %v, want full:8090", got)
+	}
+	if got := first(Constant, nil); got != "backup:8090" {
+		t.Errorf("constant call after reverts went to %v, want backup:8090", got)
+	}
+
+	// single failure doesn't move traffic from primary, it cools down after maxFailures in a row
+	failing := map[string]error{"full:8090": errors.New("connection refused")}
+	first(Read, failing)
+	if got := first(Read, nil); got != "full:8090" {
+		t.Errorf("read after single failure went to %v, want full:8090", got)
+	}
+	for i := 0; i < maxFailures; i++ {
+		first(Read, failing)
+	}
+	if got := first(Read, nil); got != "solidity:8091" {
+		t.Errorf("read after %d failures went to %v, want solidity:8091", maxFailures, got)
+	}
+
+	// rate limited endpoint cools down, the one which recovers first is used when nothing else is left
+	limited := errors.New("429 Too Many Requests")
+	first(Read, map[string]error{"solidity:8091": limited})
+	e := p.endpoints[1]
+	if _, cools := e.health(time.Now()); !cools {
+		t.Errorf("endpoint %v is not cooling down after 429", e.name)
+	}
+	if got := first(Read, nil); got != "backup:8090" {
+		t.Errorf("read after 429 went to %v, want backup:8090", got)
+	}
+	all := map[string]error{}
+	for _, e := range p.endpoints {
+		all[e.name] = limited
+	}
+	first(Read, all)
+	// backup was limited first in the last pass
+	if got := first(Read, nil); got != "backup:8090" {
+		t.Errorf("read when every endpoint cools down went to %v, want backup:8090", got)
+	}
+}
+
+func TestEndpoint_failure(t *testing.T) {
+	now := time.Now()
+	e := newEndpoint("node", "http://node", models.FullNode, nil, 0)
+	for i := 1; i < maxFailures; i++ {
+		e.failure(now, time.Minute, false)
+	}
+	if _, cools := e.health(now); cools {
+		t.Fatalf("endpoint cools down after %d failures, want %d", maxFailures-1, maxFailures)
+	}
+	e.failure(now, time.Minute, false)
+	if _, cools := e.health(now.Add(59 * time.Second)); !cools {
+		t.Fatalf("endpoint is not cooling down after %d failures", maxFailures)
+	}
+	e.failure(now, time.Minute, false)
+	if _, cools := e.health(now.Add(119 * time.Second)); !cools {
+		t.Fatalf("cooldown didn't double after further failure")
+	}
+	e.success()
+	if score, cools := e.health(now); cools || score >= 1 {
+		t.Errorf("health() after success = %v, %v, want lower score without cooldown", score, cools)
+	}
+}
+
+func Test_isRateLimited(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "Status line", err: errors.New("node responded with 429 Too Many Requests"), want: true},
+		{name: "Status code", err: errors.New("unexpected status code: 429"), want: true},
+		{name: "Message", err: errors.New("too many requests, slow down"), want: true},
+		{name: "Block number", err: errors.New("block 42942900 not found"), want: false},
+		{name: "Hash", err: errors.New("transaction 9f4291c2ab not found"), want: false},
+		{name: "Address", err: errors.New("REVERT opcode executed for TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t429"), want: false},
+		{name: "Other status", err: errors.New("unexpected status code: 4290"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRateLimited(tt.err); got != tt.want {
+				t.Errorf("isRateLimited() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type recordTransport struct {
+	keys []string
+}
+
+func (r *recordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.keys = append(r.keys, req.Header.Get(KeyHeader))
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+}
+
+func TestKeyTransport_RoundTrip(t *testing.T) {
+	base := &recordTransport{}
+	transport := NewKeyTransport(base, []string{"a", "b"})
+	for _, u := range []string{TrongridURL + "/wallet/getnowblock", TrongridURL + "/wallet/getnowblock", "http://full:8090/wallet/getnowblock", TrongridURL} {
+		req, _ := http.NewRequest(http.MethodPost, u, http.NoBody)
+		if _, err := transport.RoundTrip(req); err != nil {
+			t.Fatal(err)
+		}
+		if req.Header.Get(KeyHeader) != "" {
+			t.Fatalf("RoundTrip() modified original request")
+		}
+	}
+	want := []string{"a", "b", "", "a"}
+	for i := range want {
+		if base.keys[i] != want[i] {
+			t.Fatalf("RoundTrip() keys = %v, want %v", base.keys, want)
+		}
+	}
+}
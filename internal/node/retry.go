@@ -0,0 +1,84 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"regexp"
+	"time"
+
+	"github.com/kattana-io/tron-blocks-parser/internal/metrics"
+	"go.uber.org/zap"
+)
+
+// permanentError - error which is returned by every node, so retry won't help
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent - mark error of call as permanent, it is neither retried nor failed over
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// Retryable - errors of node api are opaque, so they are transient unless marked as permanent,
+// contract reverted or request was rejected with 4xx
+func Retryable(err error) bool {
+	var permanent *permanentError
+	return err != nil && !errors.As(err, &permanent) && !errors.Is(err, ErrNoEndpoint) &&
+		!isReverted(err) && !isClientError(err)
+}
+
+// clientError - status line of rejected request, e.g. "400 Bad Request" or "status code 404"
+var clientError = regexp.MustCompile(`\b4\d\d [A-Z][a-z]|(?i:status(?: code)?:? 4\d\d)`)
+
+// isClientError - 4xx response except 429, request is wrong for every node
+func isClientError(err error) bool {
+	return !isRateLimited(err) && clientError.MatchString(err.Error())
+}
+
+// WithBlockDeadline - context which limits node calls of single block including retries
+func (p *Pool) WithBlockDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	if p.retry.BlockDeadline <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, p.retry.BlockDeadline)
+}
+
+// withRetry - repeat pass over endpoints with jittered exponential backoff while error is retryable
+func (p *Pool) withRetry(ctx context.Context, method string, pass func() error) error {
+	backoff := p.retry.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := pass()
+		if !Retryable(err) || attempt >= p.retry.Attempts || ctx.Err() != nil {
+			return err
+		}
+		delay := jitter(backoff)
+		metrics.NodeRetries.WithLabelValues(method).Inc()
+		p.log.Warn("Node call failed on every endpoint, retrying",
+			zap.String("method", method),
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", delay),
+			zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(delay):
+		}
+		backoff *= 2
+		if backoff > p.retry.MaxBackoff {
+			backoff = p.retry.MaxBackoff
+		}
+	}
+}
+
+// jitter - random delay within [d/2, d], parsers which failed together don't retry together
+func jitter(d time.Duration) time.Duration {
+	half := int64(d / 2)
+	return time.Duration(half + rand.Int63n(int64(d)-half+1))
+}
@@ -0,0 +1,169 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kattana-io/tron-blocks-parser/internal/config"
+	"go.uber.org/zap"
+)
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "No error", err: nil, want: false},
+		{name: "Node error", err: errors.New("503 Service Unavailable"), want: true},
+		{name: "Permanent", err: Permanent(errors.New("not a token")), want: false},
+		{name: "Wrapped permanent", err: fmt.Errorf("full:8090: %w", Permanent(errors.New("not a token"))), want: false},
+		{name: "No endpoint", err: ErrNoEndpoint, want: false},
+		{name: "Contract reverted", err: errors.New("REVERT opcode executed"), want: false},
+		{name: "Contract validate error", err: errors.New("CONTRACT_VALIDATE_ERROR"), want: false},
+		{name: "Bad request", err: errors.New("node responded with 400 Bad Request"), want: false},
+		{name: "Status code", err: errors.New("unexpected status code: 404"), want: false},
+		{name: "Rate limited", err: errors.New("node responded with 429 Too Many Requests"), want: true},
+		{name: "Server error", err: errors.New("node responded with 502 Bad Gateway"), want: true},
+		{name: "Number in message", err: errors.New("read 400 bytes of block 41234567"), want: true},
+		{name: "429 in number of bad request", err: errors.New("400 Bad Request: block 42942900"), want: false},
+		{name: "Empty block", err: Behind(errors.New("empty response")), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Retryable(tt.err); got != tt.want {
+				t.Errorf("Retryable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPool_withRetry(t *testing.T) {
+	errNode := errors.New("connection refused")
+	tests := []struct {
+		name string
+		// errs - result of every pass, passes after the last one succeed
+		errs         []error
+		wantPasses   int
+		wantErr      bool
+		cancelBefore bool
+	}{
+		{name: "Success", errs: nil, wantPasses: 1},
+		{name: "Success after transient errors", errs: []error{errNode, errNode}, wantPasses: 3},
+		{name: "Attempts are over", errs: []error{errNode, errNode, errNode, errNode}, wantPasses: 3, wantErr: true},
+		{name: "Permanent error", errs: []error{Permanent(errNode)}, wantPasses: 1, wantErr: true},
+		{name: "Cancelled context", errs: []error{errNode, errNode}, wantPasses: 1, wantErr: true, cancelBefore: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(config.Node{
+				TrongridRateLimit: 10,
+				Cooldown:          time.Minute,
+				Retry:             config.Retry{Attempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond},
+			}, zap.NewNop())
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancelBefore {
+				cancel()
+			}
+
+			passes := 0
+			err := p.withRetry(ctx, "test", func() error {
+				passes++
+				if passes <= len(tt.errs) {
+					return tt.errs[passes-1]
+				}
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("withRetry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if passes != tt.wantPasses {
+				t.Errorf("withRetry() passes = %v, want %v", passes, tt.wantPasses)
+			}
+		})
+	}
+}
+
+func TestPool_do_retryable(t *testing.T) {
+	unsupported := errors.New("this node doesnt support constant")
+	tests := []struct {
+		name string
+		kind Kind
+		// errs - result of call by endpoint, missing endpoint succeeds
+		errs          map[string]error
+		wantCalls     int
+		wantRetryable bool
+	}{
+		{
+			name:      "Revert is not failed over",
+			kind:      Constant,
+			errs:      map[string]error{"full:8090": errors.New("REVERT opcode executed")},
+			wantCalls: 1,
+		},
+		{
+			name:      "Bad request is not failed over",
+			kind:      Read,
+			errs:      map[string]error{"full:8090": errors.New("node responded with 400 Bad Request")},
+			wantCalls: 1,
+		},
+		{
+			name:      "No node supports constant calls",
+			kind:      Constant,
+			errs:      map[string]error{"full:8090": unsupported, "backup:8090": unsupported, trongridName: unsupported},
+			wantCalls: 3,
+		},
+		{
+			name:          "Node failure is retried",
+			kind:          Constant,
+			errs:          map[string]error{"full:8090": unsupported, "backup:8090": errors.New("EOF"), trongridName: unsupported},
+			wantCalls:     3,
+			wantRetryable: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := createPool()
+			calls := 0
+			err := p.do(context.Background(), tt.kind, "test", func(e *endpoint) error {
+				calls++
+				return tt.errs[e.name]
+			})
+			if err == nil || Retryable(err) != tt.wantRetryable {
+				t.Errorf("do() error = %v, want retryable %v", err, tt.wantRetryable)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("do() calls = %v, want %v", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestPool_WithBlockDeadline(t *testing.T) {
+	p := New(config.Node{Retry: config.Retry{BlockDeadline: time.Minute}}, zap.NewNop())
+	ctx, cancel := p.WithBlockDeadline(context.Background())
+	defer cancel()
+	if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > time.Minute {
+		t.Errorf("WithBlockDeadline() deadline = %v, %v, want within a minute", deadline, ok)
+	}
+
+	p = New(config.Node{}, zap.NewNop())
+	ctx, cancel = p.WithBlockDeadline(context.Background())
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Errorf("WithBlockDeadline() set deadline when it is disabled")
+	}
+}
+
+func Test_jitter(t *testing.T) {
+	for _, d := range []time.Duration{0, time.Millisecond, time.Second} {
+		for i := 0; i < 100; i++ {
+			if got := jitter(d); got < d/2 || got > d {
+				t.Fatalf("jitter(%v) = %v, want within [%v, %v]", d, got, d/2, d)
+			}
+		}
+	}
+}
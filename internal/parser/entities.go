@@ -0,0 +1,67 @@
+package parser
+
+import (
+	models "github.com/kattana-io/models/pkg/storage"
+	"github.com/kattana-io/tron-blocks-parser/internal/encoding"
+	internalModels "github.com/kattana-io/tron-blocks-parser/internal/models"
+)
+
+// EntityBatch - all events of one entity of block
+type EntityBatch[T any] struct {
+	Block  *models.Block `json:"block"`
+	Events []T           `json:"events"`
+}
+
+// EntityEvent - single event with the block it belongs to
+type EntityEvent[T any] struct {
+	Block *models.Block `json:"block"`
+	Event T             `json:"event"`
+}
+
+/**
+ * GetEncodedEntities - split parsed block by entities for fan-out topics,
+ * batch - one message per entity instead of one per event, entities without events are omitted
+ * Should be called after GetEncodedBlock, so block timestamp is in seconds
+ */
+func (p *Parser) GetEncodedEntities(enc encoding.Encoder, batch bool) (map[internalModels.Entity][][]byte, error) {
+	result := make(map[internalModels.Entity][][]byte)
+	var err error
+	if result[internalModels.PairSwaps], err = encodeEntity(enc, p.state.Block, p.state.PairSwaps, batch); err != nil {
+		return nil, err
+	}
+	if result[internalModels.DirectSwaps], err = encodeEntity(enc, p.state.Block, p.state.DirectSwaps, batch); err != nil {
+		return nil, err
+	}
+	if result[internalModels.Liquidities], err = encodeEntity(enc, p.state.Block, p.state.Liquidities, batch); err != nil {
+		return nil, err
+	}
+	if result[internalModels.Pairs], err = encodeEntity(enc, p.state.Block, p.state.Pairs, batch); err != nil {
+		return nil, err
+	}
+	if result[internalModels.Transfers], err = encodeEntity(enc, p.state.Block, p.state.Transfers, batch); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func encodeEntity[T any](enc encoding.Encoder, block *models.Block, events []T, batch bool) ([][]byte, error) {
+	if len(events) == 0 {
+		return nil, nil
+	}
+	if batch {
+		b, err := enc.Encode(EntityBatch[T]{Block: block, Events: events})
+		if err != nil {
+			return nil, err
+		}
+		return [][]byte{b}, nil
+	}
+	msgs := make([][]byte, 0, len(events))
+	for _, event := range events {
+		b, err := enc.Encode(EntityEvent[T]{Block: block, Event: event})
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, b)
+	}
+	return msgs, nil
+}
@@ -6,15 +6,17 @@ package parser
 
 import (
 	"math/big"
-	"os"
 	"time"
 
 	commonModels "github.com/kattana-io/models/pkg/storage"
+	"github.com/kattana-io/tron-blocks-parser/internal/converters"
 	"github.com/kattana-io/tron-blocks-parser/internal/helper"
+	"github.com/kattana-io/tron-blocks-parser/internal/metrics"
 	"github.com/kattana-io/tron-blocks-parser/internal/models"
 	abstractPair "github.com/kattana-io/tron-blocks-parser/internal/pair"
 	tronApi "github.com/kattana-io/tron-objects-api/pkg/api"
 	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
 )
 
 const (
@@ -39,6 +41,32 @@ const jmUniV2SyncEventID = 0x1c411e9a // 0x1c411e9a96e071241c2f21f7726b17ae89e3c
 const SwftSwapEvent = 0x45f377f8
 const Univ3EventidShort = 0xc42079f9
 
+// eventNames - names of supported events for metrics
+var eventNames = map[int]string{
+	transferEvent:      "transfer",
+	tokenPurchaseEvent: "token_purchase",
+	trxPurchaseEvent:   "trx_purchase",
+	snapshotEvent:      "snapshot",
+	listingEvent:       "listing",
+	jmListingEvent:     "jm_listing",
+	jmUniv2SwapEvent:   "jm_swap",
+	jmUniV2SyncEventID: "jm_sync",
+	SwftSwapEvent:      "swft_swap",
+	Univ3EventidShort:  "univ3_swap",
+}
+
+func eventName(methodID int) string {
+	if name, ok := eventNames[methodID]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// isPriceEvent - events which update fiat prices of tokens
+func isPriceEvent(methodID int) bool {
+	return methodID == snapshotEvent
+}
+
 func isBase58(input string) bool {
 	return input[0] == 'T'
 }
@@ -55,6 +83,11 @@ func (p *Parser) processLog(log tronApi.Log, tx string, timestamp int64, owner s
 		return
 	}
 	methodID := getMethodID(log.Topics[0])
+	if p.pricesOnly && !isPriceEvent(methodID) {
+		return
+	}
+	metrics.LogsProcessed.WithLabelValues(eventName(methodID)).Inc()
+	p.logs[eventName(methodID)]++
 
 	ownerAddress := getAddressObject(owner)
 	switch methodID {
@@ -119,7 +152,7 @@ func (p *Parser) onTokenPurchase(log tronApi.Log, tx string, timestamp int64) {
 	tokenA, tokenB, ok := p.GetPairTokens(pair, abstractPair.Sunswap)
 
 	if !ok {
-		p.log.Error("Could not dissolve pair: onTokenPurchase")
+		p.log.Error("Could not dissolve pair: onTokenPurchase", zap.String("tx", tx))
 	}
 
 	// Normalize amounts
@@ -131,15 +164,17 @@ func (p *Parser) onTokenPurchase(log tronApi.Log, tx string, timestamp int64) {
 	tokenAmount := tokenAmountRaw.Div(decimal.New(1, tokenA.Decimals))
 
 	if tokenAmount.IsZero() || trxAmount.IsZero() {
-		p.log.Warn("Skipping division by zero")
+		p.log.Warn("Skipping division by zero", zap.String("tx", tx))
 		return
 	}
 	// Calculate prices
 	priceA := trxAmount.Div(tokenAmount)
 	priceB := tokenAmount.Div(trxAmount)
 
-	priceAUSD, priceBUSD := p.fiatConverter.ConvertAB(tokenA.Address, tokenB.Address, priceA)
-	valueUSD := p.calculateValueInUSD(tokenAmountRaw.BigInt(), trxAmount.BigInt(), pair, abstractPair.Sunswap)
+	conv := p.convert(tx, tokenA.Address, tokenB.Address, priceA)
+	priceAUSD, priceBUSD := conv.PriceAUSD, conv.PriceBUSD
+	p.observeSwap(tokenA.Address, tokenB.Address, priceAUSD, priceBUSD, tokenAmount, trxAmount)
+	valueUSD, valueOrigin := p.calculateValueInUSD(tokenAmountRaw.BigInt(), trxAmount.BigInt(), pair, abstractPair.Sunswap)
 
 	swap := commonModels.PairSwap{
 		Tx:          tx,
@@ -159,7 +194,7 @@ func (p *Parser) onTokenPurchase(log tronApi.Log, tx string, timestamp int64) {
 		Order:       0,
 		ValueUSD:    valueUSD,
 	}
-	p.state.AddTrade(&swap)
+	p.state.AddTrade(&swap, newProvenance(conv, valueOrigin))
 }
 
 // topics - buyer, tokens_sold, trx_bought
@@ -170,7 +205,7 @@ func (p *Parser) onTrxPurchase(log tronApi.Log, tx string, timestamp int64) {
 	tokenA, tokenB, ok := p.GetPairTokens(pair, abstractPair.Sunswap)
 
 	if !ok {
-		p.log.Error("Could not dissolve tokens: onTrxPurchase")
+		p.log.Error("Could not dissolve tokens: onTrxPurchase", zap.String("tx", tx))
 		return
 	}
 	// Normalize amounts
@@ -182,7 +217,7 @@ func (p *Parser) onTrxPurchase(log tronApi.Log, tx string, timestamp int64) {
 	trxAmount := trxAmountRaw.Div(decimal.New(1, tokenB.Decimals))
 
 	if tokenAmount.IsZero() || trxAmount.IsZero() {
-		p.log.Warn("Skipping division by zero")
+		p.log.Warn("Skipping division by zero", zap.String("tx", tx))
 		return
 	}
 
@@ -190,8 +225,10 @@ func (p *Parser) onTrxPurchase(log tronApi.Log, tx string, timestamp int64) {
 	priceA := trxAmount.Div(tokenAmount)
 	priceB := tokenAmount.Div(trxAmount)
 
-	priceAUSD, priceBUSD := p.fiatConverter.ConvertAB(tokenA.Address, tokenB.Address, priceA)
-	valueUSD := p.calculateValueInUSD(tokenAmountRaw.BigInt(), trxAmountRaw.BigInt(), pair, abstractPair.Sunswap)
+	conv := p.convert(tx, tokenA.Address, tokenB.Address, priceA)
+	priceAUSD, priceBUSD := conv.PriceAUSD, conv.PriceBUSD
+	p.observeSwap(tokenA.Address, tokenB.Address, priceAUSD, priceBUSD, tokenAmount, trxAmount)
+	valueUSD, valueOrigin := p.calculateValueInUSD(tokenAmountRaw.BigInt(), trxAmountRaw.BigInt(), pair, abstractPair.Sunswap)
 
 	swap := commonModels.PairSwap{
 		Tx:          tx,
@@ -211,7 +248,7 @@ func (p *Parser) onTrxPurchase(log tronApi.Log, tx string, timestamp int64) {
 		Order:       0,
 		ValueUSD:    valueUSD,
 	}
-	p.state.AddTrade(&swap)
+	p.state.AddTrade(&swap, newProvenance(conv, valueOrigin))
 }
 
 const (
@@ -222,7 +259,7 @@ const (
 // topics - operator, trx_balance, token_balance
 func (p *Parser) onPairSnapshot(log tronApi.Log, tx string, timestamp int64) {
 	if len(log.Topics) != SyncTopicsCount {
-		p.log.Error("onPairSnapshot: Invalid length of topics")
+		p.log.Error("onPairSnapshot: Invalid length of topics", zap.String("tx", tx))
 		return
 	}
 	pair := tronApi.FromHex(log.Address)
@@ -232,7 +269,7 @@ func (p *Parser) onPairSnapshot(log tronApi.Log, tx string, timestamp int64) {
 	tokenA, tokenB, ok := p.GetPairTokens(pair, abstractPair.Sunswap)
 
 	if !ok {
-		p.log.Error("Could not dissolve tokens: onTrxPurchase")
+		p.log.Error("Could not dissolve tokens: onPairSnapshot", zap.String("tx", tx))
 		return
 	}
 	// Normalize amounts
@@ -244,7 +281,7 @@ func (p *Parser) onPairSnapshot(log tronApi.Log, tx string, timestamp int64) {
 	trxAmount := trxAmountRaw.Div(decimal.New(1, tokenB.Decimals))
 
 	if tokenAmount.IsZero() || trxAmount.IsZero() {
-		p.log.Warn("Skipping division by zero")
+		p.log.Warn("Skipping division by zero", zap.String("tx", tx))
 		return
 	}
 
@@ -252,14 +289,16 @@ func (p *Parser) onPairSnapshot(log tronApi.Log, tx string, timestamp int64) {
 	priceA := trxAmount.Div(tokenAmount)
 	priceB := tokenAmount.Div(trxAmount)
 
-	priceAUSD, priceBUSD := p.fiatConverter.ConvertAB(tokenA.Address, tokenB.Address, priceA)
-	p.fiatConverter.UpdateTokenUSDPrice(tokenA.Address, priceAUSD)
+	conv := p.convert(tx, tokenA.Address, tokenB.Address, priceA)
+	priceAUSD, priceBUSD := conv.PriceAUSD, conv.PriceBUSD
+	// tokenA may have its own rate, so update it with the one implied by this pair
+	p.fiatConverter.UpdateTokenUSDPrice(tokenA.Address, pair.ToBase58(), priceA.Mul(priceBUSD))
 
 	// Update TRX price on USDT-TRX Pair trade
 	if pair.ToBase58() == trxusdtPair && tokenB.Address == trxAddress {
-		p.fiatConverter.UpdateTokenUSDPrice(tokenB.Address, priceB)
+		p.fiatConverter.UpdateTokenUSDPrice(tokenB.Address, pair.ToBase58(), priceB)
 	}
-	valueUSD := p.calculateReservesInUSD(tokenAmountRaw.BigInt(), trxAmountRaw.BigInt(), pair, abstractPair.Sunswap)
+	valueUSD, valueOrigin := p.calculateReservesInUSD(tokenAmountRaw.BigInt(), trxAmountRaw.BigInt(), pair, abstractPair.Sunswap)
 
 	syncEvent := commonModels.LiquidityEvent{
 		BlockNumber: p.state.Block.Number.Uint64(),
@@ -278,66 +317,94 @@ func (p *Parser) onPairSnapshot(log tronApi.Log, tx string, timestamp int64) {
 		PriceBUSD:   priceBUSD,
 		ReserveUSD:  valueUSD,
 	}
-	p.state.AddLiquidity(&syncEvent)
+	p.state.AddLiquidity(&syncEvent, newProvenance(conv, valueOrigin))
+}
+
+// convert - USD prices of pair tokens, warns when known rates disagree with the pair price
+func (p *Parser) convert(tx, tokenA, tokenB string, price decimal.Decimal) converters.Conversion {
+	conv := p.fiatConverter.ConvertAB(tokenA, tokenB, price)
+	if !conv.Consistent {
+		p.log.Warn("USD rates disagree with pair price",
+			zap.String("tx", tx),
+			zap.String("tokenA", tokenA),
+			zap.String("tokenB", tokenB),
+			zap.Stringer("price", price),
+			zap.Stringer("deviation", conv.Deviation))
+	}
+	return conv
+}
+
+// observeSwap - register traded amounts for per block price aggregation
+func (p *Parser) observeSwap(tokenA, tokenB string, priceAUSD, priceBUSD, amountA, amountB decimal.Decimal) {
+	p.fiatConverter.Observe(tokenA, priceAUSD, amountA)
+	p.fiatConverter.Observe(tokenB, priceBUSD, amountB)
 }
 
 // Dissolve pair into tokens, calculate values, don't multiply instead of reserves
-func (p *Parser) calculateValueInUSD(amount0, amount1 *big.Int, address *tronApi.Address, klass string) decimal.Decimal {
+func (p *Parser) calculateValueInUSD(amount0, amount1 *big.Int,
+	address *tronApi.Address,
+	klass string) (decimal.Decimal, converters.Provenance) {
 	tokenA, tokenB, ok := p.GetPairTokens(address, klass)
 	if !ok {
-		p.log.Warn("[calculateValueInUSD] Could not get pair:" + address.ToBase58())
-		return decimal.NewFromInt(0)
+		p.log.Warn("calculateValueInUSD: could not get pair", zap.String("pair", address.ToBase58()))
+		return decimal.NewFromInt(0), converters.Provenance{}
 	}
 
-	valueA, okA := p.calculateReservesForToken(tokenA, amount0)
-	valueB, okB := p.calculateReservesForToken(tokenB, amount1)
+	valueA, originA, okA := p.calculateReservesForToken(tokenA, amount0)
+	valueB, originB, okB := p.calculateReservesForToken(tokenB, amount1)
 
 	if okA && okB {
-		minValue := decimal.Min(valueA, valueB)
-		return minValue
+		if valueA.LessThanOrEqual(valueB) {
+			return valueA, originA
+		}
+		return valueB, originB
 	}
 	if okA {
-		return valueA
+		return valueA, originA
 	}
 	if okB {
-		return valueB
+		return valueB, originB
 	}
 
-	return decimal.NewFromInt(0)
+	return decimal.NewFromInt(0), converters.Provenance{}
 }
 
 // Dissolve pair into tokens, calculate values
-func (p *Parser) calculateReservesInUSD(reserves0, reserves1 *big.Int, address *tronApi.Address, klass string) decimal.Decimal {
+func (p *Parser) calculateReservesInUSD(reserves0, reserves1 *big.Int,
+	address *tronApi.Address,
+	klass string) (decimal.Decimal, converters.Provenance) {
 	tokenA, tokenB, ok := p.GetPairTokens(address, klass)
 	if !ok {
-		p.log.Warn("[calculateReservesInUSD] Could not get pair:" + address.ToBase58())
-		return decimal.NewFromInt(0)
+		p.log.Warn("calculateReservesInUSD: could not get pair", zap.String("pair", address.ToBase58()))
+		return decimal.NewFromInt(0), converters.Provenance{}
 	}
 
-	reservesA, okA := p.calculateReservesForToken(tokenA, reserves0)
-	reservesB, okB := p.calculateReservesForToken(tokenB, reserves1)
+	reservesA, originA, okA := p.calculateReservesForToken(tokenA, reserves0)
+	reservesB, originB, okB := p.calculateReservesForToken(tokenB, reserves1)
 
 	if okA && okB {
-		minReserve := decimal.Min(reservesA, reservesB)
-		return minReserve.Mul(decimal.NewFromInt(2))
+		if reservesA.LessThanOrEqual(reservesB) {
+			return reservesA.Mul(decimal.NewFromInt(2)), originA
+		}
+		return reservesB.Mul(decimal.NewFromInt(2)), originB
 	}
 	if okA {
-		return reservesA.Mul(decimal.NewFromInt(2))
+		return reservesA.Mul(decimal.NewFromInt(2)), originA
 	}
 	if okB {
-		return reservesB.Mul(decimal.NewFromInt(2))
+		return reservesB.Mul(decimal.NewFromInt(2)), originB
 	}
 
-	return decimal.NewFromInt(0)
+	return decimal.NewFromInt(0), converters.Provenance{}
 }
 
 // calculateReservesForToken -- calculates reserves for a specific token using previous price
-func (p *Parser) calculateReservesForToken(token *models.Token, reserves *big.Int) (decimal.Decimal, bool) {
-	priceUSD := p.fiatConverter.GetPriceOfToken(token.Address)
+func (p *Parser) calculateReservesForToken(token *models.Token, reserves *big.Int) (decimal.Decimal, converters.Provenance, bool) {
+	priceUSD, origin := p.fiatConverter.GetPriceOfToken(token.Address)
 	if !priceUSD.IsZero() {
-		return decimal.NewFromBigInt(reserves, -token.Decimals).Mul(priceUSD), true
+		return decimal.NewFromBigInt(reserves, -token.Decimals).Mul(priceUSD), origin, true
 	}
-	return decimal.NewFromInt(0), false
+	return decimal.NewFromInt(0), converters.Provenance{}, false
 }
 
 // onPairCreated - handle listing event
@@ -345,8 +412,7 @@ func (p *Parser) calculateReservesForToken(token *models.Token, reserves *big.In
 func (p *Parser) onPairCreated(log tronApi.Log, timestamp int64) {
 	factory := tronApi.FromHex(log.Address)
 	pair := tronApi.FromHex(tronApi.TrimZeroes(log.Topics[2]))
-	nodeURL := os.Getenv("FULL_NODE_URL")
-	p.state.RegisterNewPair(factory.ToBase58(), pair.ToBase58(), "sunswap", Chain, nodeURL, time.Unix(timestamp, 0))
+	p.state.RegisterNewPair(factory.ToBase58(), pair.ToBase58(), "sunswap", Chain, p.nodeURL, time.Unix(timestamp, 0))
 }
 
 // convert "ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef" -> 0xddf252ad
@@ -2,7 +2,6 @@ package parser
 
 import (
 	"math/big"
-	"os"
 	"strings"
 	"time"
 
@@ -24,7 +23,7 @@ func (p *Parser) onJmPairCreated(log tronApi.Log, timestamp int64) {
 
 	factoryAbi, err := abi.JSON(strings.NewReader(JMFactoryABI))
 	if err != nil {
-		p.log.Warn("Could not parse factory abi: ", zap.Error(err))
+		p.log.Warn("Could not parse factory abi", zap.Error(err))
 		return
 	}
 
@@ -42,8 +41,7 @@ func (p *Parser) onJmPairCreated(log tronApi.Log, timestamp int64) {
 		}
 		pairAddress := data["pair"].(common.Address)
 		pair := tronApi.FromHex(pairAddress.Hex())
-		nodeURL := os.Getenv("FULL_NODE_URL")
-		p.state.RegisterNewPair(factory.ToBase58(), pair.ToBase58(), "justmoney", Chain, nodeURL, time.Unix(timestamp, 0))
+		p.state.RegisterNewPair(factory.ToBase58(), pair.ToBase58(), "justmoney", Chain, p.nodeURL, time.Unix(timestamp, 0))
 	}
 }
 
@@ -90,7 +88,7 @@ func (p *Parser) onJmSyncEvent(log tronApi.Log, tx string, owner *tronApi.Addres
 		tokenA, tokenB, ok := p.GetPairTokens(pair, abstractPair.UniV2)
 		if !ok {
 			//nolint:goconst
-			p.log.Error("Could not dissolve univ2 pair: " + tx)
+			p.log.Error("Could not dissolve univ2 pair", zap.String("tx", tx))
 			return
 		}
 
@@ -107,8 +105,9 @@ func (p *Parser) onJmSyncEvent(log tronApi.Log, tx string, owner *tronApi.Addres
 			priceB = decimal.NewFromBigInt(reserves0, -tokenA.Decimals).Div(res1)
 		}
 
-		priceAUSD, priceBUSD := p.fiatConverter.ConvertAB(tokenA.Address, tokenB.Address, priceA)
-		reservesUSD := p.calculateReservesInUSD(reserves0, reserves1, pair, abstractPair.UniV2)
+		conv := p.convert(tx, tokenA.Address, tokenB.Address, priceA)
+		priceAUSD, priceBUSD := conv.PriceAUSD, conv.PriceBUSD
+		reservesUSD, valueOrigin := p.calculateReservesInUSD(reserves0, reserves1, pair, abstractPair.UniV2)
 
 		sync := commonModels.LiquidityEvent{
 			BlockNumber: p.state.Block.Number.Uint64(),
@@ -128,7 +127,7 @@ func (p *Parser) onJmSyncEvent(log tronApi.Log, tx string, owner *tronApi.Addres
 			ReserveUSD:  reservesUSD,
 		}
 
-		p.state.AddLiquidity(&sync)
+		p.state.AddLiquidity(&sync, newProvenance(conv, valueOrigin))
 	}
 }
 
@@ -153,7 +152,10 @@ func (p *Parser) onJmSwapEvent(log tronApi.Log, tx string, owner *tronApi.Addres
 		Amount0Out := data["amount0Out"].(*big.Int)
 
 		if Amount0In.String() == "1" || Amount1In.String() == "1" {
-			p.log.Warn("Bad amounts, skipping: Token0Amount: " + Amount0In.String() + " Token1Amount: " + Amount1In.String())
+			p.log.Warn("Bad amounts, skipping",
+				zap.String("tx", tx),
+				zap.Stringer("token0_amount", Amount0In),
+				zap.Stringer("token1_amount", Amount1In))
 			return
 		}
 
@@ -172,7 +174,7 @@ func (p *Parser) onJmSwapEvent(log tronApi.Log, tx string, owner *tronApi.Addres
 
 		tokenA, tokenB, ok := p.GetPairTokens(pair, abstractPair.UniV2)
 		if !ok {
-			p.log.Error("Could not dissolve univ2 pair: " + tx)
+			p.log.Error("Could not dissolve univ2 pair", zap.String("tx", tx))
 			return
 		}
 
@@ -182,8 +184,10 @@ func (p *Parser) onJmSwapEvent(log tronApi.Log, tx string, owner *tronApi.Addres
 		PriceA := naturalB.Div(naturalA)
 		PriceB := naturalA.Div(naturalB)
 
-		PriceAUSD, PriceBUSD := p.fiatConverter.ConvertAB(tokenA.Address, tokenB.Address, PriceA)
-		ValueUSD := p.calculateValueInUSD(Token0Amount, Token1Amount, pair, abstractPair.UniV2)
+		conv := p.convert(tx, tokenA.Address, tokenB.Address, PriceA)
+		PriceAUSD, PriceBUSD := conv.PriceAUSD, conv.PriceBUSD
+		p.observeSwap(tokenA.Address, tokenB.Address, PriceAUSD, PriceBUSD, naturalA, naturalB)
+		ValueUSD, valueOrigin := p.calculateValueInUSD(Token0Amount, Token1Amount, pair, abstractPair.UniV2)
 
 		trade := commonModels.PairSwap{
 			Tx:          tx,
@@ -204,10 +208,10 @@ func (p *Parser) onJmSwapEvent(log tronApi.Log, tx string, owner *tronApi.Addres
 			Order:       0,
 		}
 
-		p.state.AddTrade(&trade)
+		p.state.AddTrade(&trade, newProvenance(conv, valueOrigin))
 		return
 	} else {
-		p.log.Debug("Could not unpack event, event is nil: " + tx)
+		p.log.Debug("Could not unpack event, event is nil", zap.String("tx", tx))
 		return
 	}
 }
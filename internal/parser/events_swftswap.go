@@ -44,7 +44,7 @@ func (p *Parser) onSwftSwap(log tronApi.Log, tx string, _ *tronApi.Address, time
 		decimalsB, ok2 := p.GetTokenDecimals(addrTokenB)
 
 		if !ok1 || !ok2 {
-			p.log.Error("Could not get token decimals")
+			p.log.Error("Could not get token decimals", zap.String("tx", tx))
 		}
 
 		var priceA decimal.Decimal
@@ -61,7 +61,9 @@ func (p *Parser) onSwftSwap(log tronApi.Log, tx string, _ *tronApi.Address, time
 			priceB = naturalA.Div(naturalB)
 		}
 
-		priceAUSD, priceBUSD := p.fiatConverter.ConvertAB(addrTokenA.ToBase58(), addrTokenB.ToBase58(), priceA)
+		conv := p.convert(tx, addrTokenA.ToBase58(), addrTokenB.ToBase58(), priceA)
+		priceAUSD, priceBUSD := conv.PriceAUSD, conv.PriceBUSD
+		p.observeSwap(addrTokenA.ToBase58(), addrTokenB.ToBase58(), priceAUSD, priceBUSD, naturalA, naturalB)
 		ValueUSD := calculateValueUSDSwftswap(naturalA, naturalB, priceAUSD, priceBUSD)
 
 		dSwap := commonModels.DirectSwap{
@@ -82,7 +84,12 @@ func (p *Parser) onSwftSwap(log tronApi.Log, tx string, _ *tronApi.Address, time
 			Order:       0,
 			ValueUSD:    ValueUSD,
 		}
-		p.state.AddDirectSwap(&dSwap)
+		// value is derived from converted prices, so it shares their origin
+		valueOrigin := conv.OriginA
+		if priceAUSD.IsZero() {
+			valueOrigin = conv.OriginB
+		}
+		p.state.AddDirectSwap(&dSwap, newProvenance(conv, valueOrigin))
 	}
 }
 
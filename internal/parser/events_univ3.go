@@ -29,7 +29,10 @@ func (p *Parser) onUniV3Swap(log tronApi.Log, tx string, owner *tronApi.Address,
 		Amount1 := data["amount1"].(*big.Int)
 
 		if Amount0.String() == "1" || Amount1.String() == "1" {
-			p.log.Warn("Bad amounts, skipping: Token0Amount: " + Amount0.String() + " Token1Amount: " + Amount1.String())
+			p.log.Warn("Bad amounts, skipping",
+				zap.String("tx", tx),
+				zap.Stringer("token0_amount", Amount0),
+				zap.Stringer("token1_amount", Amount1))
 			return
 		}
 
@@ -48,7 +51,7 @@ func (p *Parser) onUniV3Swap(log tronApi.Log, tx string, owner *tronApi.Address,
 
 		tokenA, tokenB, ok := p.GetPairTokens(pair, abstractPair.UniV3)
 		if !ok {
-			p.log.Error("Could not dissolve univ2 pair: " + tx)
+			p.log.Error("Could not dissolve univ3 pair", zap.String("tx", tx))
 			return
 		}
 
@@ -58,8 +61,10 @@ func (p *Parser) onUniV3Swap(log tronApi.Log, tx string, owner *tronApi.Address,
 		PriceA := naturalB.Div(naturalA)
 		PriceB := naturalA.Div(naturalB)
 
-		PriceAUSD, PriceBUSD := p.fiatConverter.ConvertAB(tokenA.Address, tokenB.Address, PriceA)
-		ValueUSD := p.calculateValueInUSD(Amount0, Amount1, pair, abstractPair.UniV3)
+		conv := p.convert(tx, tokenA.Address, tokenB.Address, PriceA)
+		PriceAUSD, PriceBUSD := conv.PriceAUSD, conv.PriceBUSD
+		p.observeSwap(tokenA.Address, tokenB.Address, PriceAUSD, PriceBUSD, naturalA, naturalB)
+		ValueUSD, valueOrigin := p.calculateValueInUSD(Amount0, Amount1, pair, abstractPair.UniV3)
 
 		trade := commonModels.PairSwap{
 			Tx:          tx,
@@ -77,7 +82,7 @@ func (p *Parser) onUniV3Swap(log tronApi.Log, tx string, owner *tronApi.Address,
 			ValueUSD:    ValueUSD,
 			Wallet:      owner.ToBase58(),
 		}
-		p.state.AddTrade(&trade)
+		p.state.AddTrade(&trade, newProvenance(conv, valueOrigin))
 	} else {
 		p.log.Debug("Could not unpack event, event is nil", zap.String("tx", tx))
 		return
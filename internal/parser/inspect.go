@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	models "github.com/kattana-io/models/pkg/storage"
+	"github.com/kattana-io/tron-blocks-parser/internal/tracing"
+)
+
+/**
+ * Debugging of single transaction: which handler took every log and what it emitted
+ */
+
+// LogReport - handling of transaction log
+type LogReport struct {
+	Index   int      `json:"index"`
+	Address string   `json:"address"`
+	Topics  []string `json:"topics"`
+	Data    string   `json:"data"`
+	// Handler - name of event handler, unknown when log is not supported
+	Handler string `json:"handler"`
+	// Emitted - entities added to state by the handler
+	Emitted map[string]int `json:"emitted"`
+}
+
+// TransactionReport - handling of every log of transaction
+type TransactionReport struct {
+	Hash   string      `json:"hash"`
+	Block  uint64      `json:"block"`
+	Result string      `json:"result"`
+	Owner  string      `json:"owner"`
+	Logs   []LogReport `json:"logs"`
+}
+
+// InspectTransaction - run handlers over logs of single transaction, prices are not committed
+func (p *Parser) InspectTransaction(ctx context.Context, block models.Block, hash string) (*TransactionReport, error) {
+	p.state = CreateState(&block)
+	ctx, span := tracing.Start(ctx, "parser.InspectTransaction",
+		tracing.BlockNumber.Int64(block.Number.Int64()),
+		tracing.TxHash.String(hash))
+	defer span.End()
+	p.ctx = ctx
+
+	if err := p.fetchBlock(ctx, block.Number.Int64()); err != nil {
+		return nil, err
+	}
+	infos, err := p.fetchTransactionInfos(ctx, block.Number.Int64())
+	if err != nil {
+		return nil, fmt.Errorf("could not receive transactions info: %w", err)
+	}
+	for _, tx := range infos {
+		if !strings.EqualFold(tx.ID, hash) {
+			continue
+		}
+		owner, _ := p.txOwner(tx.ID)
+		report := &TransactionReport{
+			Hash:   tx.ID,
+			Block:  block.Number.Uint64(),
+			Result: tx.Receipt.Result,
+			Owner:  owner,
+			Logs:   make([]LogReport, 0, len(tx.Log)),
+		}
+		for i, log := range tx.Log {
+			logReport := LogReport{
+				Index:   i,
+				Address: log.Address,
+				Topics:  log.Topics,
+				Data:    log.Data,
+				Handler: "unknown",
+				Emitted: map[string]int{},
+			}
+			if len(log.Topics) > 0 {
+				logReport.Handler = eventName(getMethodID(log.Topics[0]))
+			}
+			// Failed transactions are skipped by Parse
+			if tx.Receipt.Result == "SUCCESS" && owner != "" {
+				before := p.Summary()
+				p.processLog(log, tx.ID, tx.BlockTimeStamp/1000, owner)
+				logReport.Emitted = p.Summary().emitted(before)
+			}
+			report.Logs = append(report.Logs, logReport)
+		}
+		return report, nil
+	}
+	return nil, fmt.Errorf("transaction %s not found in block %s", hash, block.Number)
+}
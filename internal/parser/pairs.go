@@ -2,8 +2,12 @@ package parser
 
 import (
 	"context"
+
+	"github.com/kattana-io/tron-blocks-parser/internal/metrics"
 	"github.com/kattana-io/tron-blocks-parser/internal/models"
+	"github.com/kattana-io/tron-blocks-parser/internal/node"
 	abstractPair "github.com/kattana-io/tron-blocks-parser/internal/pair"
+	"github.com/kattana-io/tron-blocks-parser/internal/tracing"
 	tronApi "github.com/kattana-io/tron-objects-api/pkg/api"
 	jmPair "github.com/kattana-io/tron-objects-api/pkg/justmoney"
 	"go.uber.org/zap"
@@ -18,10 +22,11 @@ const (
 )
 
 func (p *Parser) GetPairTokens(pair *tronApi.Address, klass string) (tokenA, tokenB *models.Token, ok bool) {
-	ctx := context.Background()
+	ctx := p.ctx
 	// Step 1: Check if pair is present in cache
 	instance, err := p.pairsCache.Get(ctx, pair.ToBase58())
 	if err != nil {
+		metrics.PairCache.WithLabelValues("miss").Inc()
 		// Step 2: Create pair instance
 		inst, ok := p.CreatePair(ctx, pair, klass)
 		if ok {
@@ -34,21 +39,28 @@ func (p *Parser) GetPairTokens(pair *tronApi.Address, klass string) (tokenA, tok
 		// Step 3: If we failed to fetch than return nil
 		return nil, nil, false
 	}
+	metrics.PairCache.WithLabelValues("hit").Inc()
 	return &instance.Token0, &instance.Token1, true
 }
 
-func (p *Parser) createToken(address *tronApi.Address) models.Token {
+func (p *Parser) createToken(ctx context.Context, address *tronApi.Address) models.Token {
+	ctx, span := tracing.Start(ctx, "parser.createToken", tracing.Token.String(address.ToBase58()))
 	// Step 1: fetch from cached token list
 	dec, ok := p.tokenLists.GetDecimals(address)
 	if ok {
-		p.log.Info("fetched decimals from list for token ", zap.String("address", address.ToBase58()))
+		p.log.Info("Fetched decimals from token list", zap.String("token", address.ToBase58()))
+		span.End()
 		return models.Token{
 			Address:  address.ToBase58(),
 			Decimals: dec,
 		}
 	}
 	// Step 2: do a static call for trc20 token
-	dec, err := p.api.GetTokenDecimals(address.ToHex())
+	err := p.nodes.Call(ctx, node.Constant, "GetTokenDecimals", func(api *tronApi.API) (err error) {
+		dec, err = api.GetTokenDecimals(address.ToHex())
+		return err
+	})
+	tracing.End(span, err)
 	if err != nil {
 		p.log.Error("createToken: GetTokenDecimals", zap.Error(err))
 	}
@@ -58,19 +70,30 @@ func (p *Parser) createToken(address *tronApi.Address) models.Token {
 	}
 }
 
-func (p *Parser) CreatePair(_ context.Context, addr *tronApi.Address, klass string) (*models.Pair, bool) {
+func (p *Parser) CreatePair(ctx context.Context, addr *tronApi.Address, klass string) (*models.Pair, bool) {
+	ctx, span := tracing.Start(ctx, "parser.CreatePair",
+		tracing.Pair.String(addr.ToBase58()),
+		tracing.PairKlass.String(klass))
+	defer span.End()
+
 	switch klass {
 	case abstractPair.UniV2:
 	case abstractPair.UniV3: // uniV3 same function names
-		instance := jmPair.New(p.api, *addr)
-		addr0, err := instance.Token0()
+		var addr0, addr1 *tronApi.Address
+		err := p.nodes.Call(ctx, node.Constant, "Token0", func(api *tronApi.API) (err error) {
+			addr0, err = jmPair.New(api, *addr).Token0()
+			return err
+		})
 		if err != nil {
 			p.log.Error("could not fetch token0",
 				zap.Error(err),
 				zap.String("pair", addr.ToBase58()))
 			return nil, false
 		}
-		addr1, err := instance.Token1()
+		err = p.nodes.Call(ctx, node.Constant, "Token1", func(api *tronApi.API) (err error) {
+			addr1, err = jmPair.New(api, *addr).Token1()
+			return err
+		})
 		if err != nil {
 			p.log.Error("could not fetch token1",
 				zap.Error(err),
@@ -80,8 +103,8 @@ func (p *Parser) CreatePair(_ context.Context, addr *tronApi.Address, klass stri
 		return &models.Pair{
 			Address: addr.ToBase58(),
 			Klass:   klass,
-			Token0:  p.createToken(addr0),
-			Token1:  p.createToken(addr1),
+			Token0:  p.createToken(ctx, addr0),
+			Token1:  p.createToken(ctx, addr1),
 		}, true
 	case abstractPair.Sunswap:
 		pair := models.Pair{
@@ -109,7 +132,7 @@ func (p *Parser) CreatePair(_ context.Context, addr *tronApi.Address, klass stri
 			return nil, false
 		}
 		tokenAddr := tronApi.FromHex(addr0)
-		pair.Token0 = p.createToken(tokenAddr)
+		pair.Token0 = p.createToken(ctx, tokenAddr)
 
 		return &pair, true
 	default:
@@ -119,14 +142,18 @@ func (p *Parser) CreatePair(_ context.Context, addr *tronApi.Address, klass stri
 	return nil, false
 }
 
-// GetSunswapToken - NOTICE This could fail due to "this node doesnt support constant"
+// GetSunswapToken - constant call, pool routes it only to nodes which support constant calls
 func (p *Parser) GetSunswapToken(addr *tronApi.Address) (string, bool) {
-	data, err := p.api.TCCRequest(map[string]any{
-		"contract_address":  addr.ToHex(),
-		"owner_address":     "4128fb7be6c95a27217e0e0bff42ca50cd9461cc9f",
-		"function_selector": "tokenAddress()",
-		"parameter":         "",
-		"call_value":        0,
+	var data *tronApi.TCCResponse
+	err := p.nodes.Call(p.ctx, node.Constant, "TCCRequest", func(api *tronApi.API) (err error) {
+		data, err = api.TCCRequest(map[string]any{
+			"contract_address":  addr.ToHex(),
+			"owner_address":     "4128fb7be6c95a27217e0e0bff42ca50cd9461cc9f",
+			"function_selector": "tokenAddress()",
+			"parameter":         "",
+			"call_value":        0,
+		})
+		return err
 	})
 
 	if err != nil || len(data.ConstantResult) == 0 {
@@ -1,23 +1,29 @@
 package parser
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"sync"
-
-	"github.com/kattana-io/tron-objects-api/pkg/trc20"
+	"time"
 
 	models "github.com/kattana-io/models/pkg/storage"
 	"github.com/kattana-io/tron-blocks-parser/internal/abi"
 	"github.com/kattana-io/tron-blocks-parser/internal/cache"
 	"github.com/kattana-io/tron-blocks-parser/internal/converters"
+	"github.com/kattana-io/tron-blocks-parser/internal/encoding"
 	"github.com/kattana-io/tron-blocks-parser/internal/integrations"
+	"github.com/kattana-io/tron-blocks-parser/internal/metrics"
+	"github.com/kattana-io/tron-blocks-parser/internal/node"
+	"github.com/kattana-io/tron-blocks-parser/internal/tracing"
 	tronApi "github.com/kattana-io/tron-objects-api/pkg/api"
-	"github.com/vmihailenco/msgpack/v5"
 	"go.uber.org/zap"
 )
 
+var errEmptyBlock = errors.New("empty response")
+
 type Parser struct {
-	api           *tronApi.API
+	nodes         *node.Pool
 	failedTx      []tronApi.Transaction
 	txMap         sync.Map
 	state         *State
@@ -26,39 +32,80 @@ type Parser struct {
 	abiHolder     *abi.Holder
 	tokenLists    *integrations.TokenListsProvider
 	sunswapPairs  *integrations.SunswapProvider
-	log           *zap.SugaredLogger
+	log           *zap.Logger
+	nodeURL       string
+	pricesOnly    bool
+	dryRun        bool
+	err           error
+	transactions  int
+	logs          map[string]int
+	// ctx - span context of the stage being parsed, lives for a single block
+	ctx context.Context
 }
 
 // Parse - parse single block
-func (p *Parser) Parse(block models.Block) bool {
+func (p *Parser) Parse(ctx context.Context, block models.Block) bool {
 	p.state = CreateState(&block)
+	defer func(start time.Time) {
+		metrics.ParseDuration.Observe(time.Since(start).Seconds())
+	}(time.Now())
+
+	ctx, span := tracing.Start(ctx, "parser.Parse",
+		tracing.BlockNumber.Int64(block.Number.Int64()),
+		tracing.BlockNetwork.String(block.Network))
+	defer func() { tracing.End(span, p.err) }()
+	ctx, cancel := p.nodes.WithBlockDeadline(ctx)
+	defer cancel()
+	p.ctx = ctx
 
-	resp, err := p.api.GetBlockByNum(int32(block.Number.Int64()))
-	if resp.BlockID == "" {
-		p.log.Error("could not receive block: ", zap.Error(err))
+	if err := p.fetchBlock(ctx, block.Number.Int64()); err != nil {
+		p.log.Error("Could not receive block", zap.Error(err))
+		p.err = err
 		return false
 	}
-	if err != nil {
-		p.log.Error("Parse: " + err.Error())
+	p.log.Debug("Parsing block", zap.Int("transactions", p.transactions))
+
+	if err := p.parseTransactions(ctx, block.Number.Int64()); err != nil {
+		p.log.Error("Could not parse transactions", zap.Error(err))
+		p.err = err
 		return false
 	}
-
-	p.log.Info("Parsing block: " + block.Number.String())
-
-	cnt := 0
-	for i := range resp.Transactions {
-		cnt++
-		p.txMap.Store(resp.Transactions[i].TxID, &resp.Transactions[i])
+	// Handlers skip entities whose node calls failed, such block is incomplete
+	if err := ctx.Err(); err != nil {
+		p.err = fmt.Errorf("node calls of block are not finished: %w", err)
+		return false
 	}
 
-	p.parseTransactions(block.Number.Int64())
-	p.log.Info(fmt.Sprintf("Parsing transactions: %v", cnt))
-
 	// save prices
-	p.fiatConverter.Commit()
+	p.state.Prices = p.fiatConverter.BlockPrices()
+	if !p.dryRun {
+		p.fiatConverter.Commit(ctx)
+	}
 	return true
 }
 
+// State - parsed entities of block
+func (p *Parser) State() *State {
+	return p.state
+}
+
+// Err - reason why Parse failed
+func (p *Parser) Err() error {
+	return p.err
+}
+
+// ParsePrices - first pass of history backfill, handle only price events to build checkpoints
+func (p *Parser) ParsePrices(ctx context.Context, block models.Block) bool {
+	p.pricesOnly = true
+	return p.Parse(ctx, block)
+}
+
+// ParseDryRun - parse block requested on demand, prices of block are not stored to Redis
+func (p *Parser) ParseDryRun(ctx context.Context, block models.Block) bool {
+	p.dryRun = true
+	return p.Parse(ctx, block)
+}
+
 // hasContractCalls - trading events are always contract calls
 func hasContractCalls(transaction *tronApi.Transaction) bool {
 	return len(transaction.RawData.Contract) >= 1
@@ -77,13 +124,63 @@ func isSuccessCall(transaction *tronApi.Transaction) bool {
 	return transaction.Ret[0].ContractRet == "SUCCESS"
 }
 
-// parseTransactions - downloads block transactions and logs
-func (p *Parser) parseTransactions(blockNumber int64) {
-	resp, err := p.api.GetTransactionInfoByBlockNum(blockNumber)
+// fetchBlock - downloads block and indexes its transactions by id
+func (p *Parser) fetchBlock(ctx context.Context, blockNumber int64) error {
+	ctx, span := tracing.Start(ctx, "node.GetBlockByNum")
+	var resp *tronApi.Block
+	err := p.nodes.Call(ctx, node.Read, "GetBlockByNum", func(api *tronApi.API) (err error) {
+		resp, err = api.GetBlockByNum(int32(blockNumber))
+		if err == nil && resp.BlockID == "" {
+			// node is behind, the next endpoint may already have the block
+			return node.Behind(errEmptyBlock)
+		}
+		return err
+	})
+	tracing.End(span, err)
+	if err != nil {
+		return fmt.Errorf("could not receive block: %w", err)
+	}
 
+	for i := range resp.Transactions {
+		p.transactions++
+		p.txMap.Store(resp.Transactions[i].TxID, &resp.Transactions[i])
+	}
+	return nil
+}
+
+// fetchTransactionInfos - downloads logs of block transactions
+func (p *Parser) fetchTransactionInfos(ctx context.Context, blockNumber int64) ([]tronApi.TransactionInfo, error) {
+	ctx, span := tracing.Start(ctx, "node.GetTransactionInfoByBlockNum")
+	var resp []tronApi.TransactionInfo
+	err := p.nodes.Call(ctx, node.Read, "GetTransactionInfoByBlockNum", func(api *tronApi.API) (err error) {
+		resp, err = api.GetTransactionInfoByBlockNum(blockNumber)
+		return err
+	})
+	tracing.End(span, err)
 	if err != nil {
-		p.log.Error("parseTransaction: " + err.Error())
-		return
+		return nil, err
+	}
+	// Blocks requested by number come without timestamp
+	if p.state.Block.Timestamp == 0 && len(resp) > 0 {
+		p.state.Block.Timestamp = uint64(resp[0].BlockTimeStamp)
+	}
+	return resp, nil
+}
+
+// txOwner - caller of transaction, false when transaction is not in the block
+func (p *Parser) txOwner(id string) (string, bool) {
+	txRaw, ok := p.txMap.Load(id)
+	if !ok {
+		return "", false
+	}
+	return txRaw.(*tronApi.Transaction).RawData.Contract[0].Parameter.Value.OwnerAddress, true
+}
+
+// parseTransactions - downloads block transactions and logs
+func (p *Parser) parseTransactions(ctx context.Context, blockNumber int64) error {
+	resp, err := p.fetchTransactionInfos(ctx, blockNumber)
+	if err != nil {
+		return fmt.Errorf("could not receive transactions info: %w", err)
 	}
 
 	for _, tx := range resp {
@@ -92,41 +189,43 @@ func (p *Parser) parseTransactions(blockNumber int64) {
 		}
 
 		t := tx.BlockTimeStamp / 1000
+		txCtx, span := tracing.Start(ctx, "parser.Transaction", tracing.TxHash.String(tx.ID))
+		p.ctx = txCtx
 		// Process logs
 		for _, log := range tx.Log {
-			txRaw, ok := p.txMap.Load(tx.ID)
-
+			owner, ok := p.txOwner(tx.ID)
 			if !ok {
 				continue
 			}
-			owner := txRaw.(*tronApi.Transaction).RawData.Contract[0].Parameter.Value.OwnerAddress
-
 			p.processLog(log, tx.ID, t, owner)
 		}
+		span.End()
 	}
+	p.ctx = ctx
+	return nil
 }
 
-func (p *Parser) GetEncodedBlock() []byte {
+func (p *Parser) GetEncodedBlock(enc encoding.Encoder) []byte {
 	p.state.Block.Timestamp /= 1000 // consumer service expect to get timestamp in seconds
-	b, err := msgpack.Marshal(p.state)
+	b, err := enc.Encode(p.state)
 	if err != nil {
-		p.log.Warn("GetEncodedBlock: " + err.Error())
+		p.log.Warn("Could not encode block", zap.Error(err))
 		return nil
 	}
 	return b
 }
 
-func (p *Parser) GetEncodedHolders() []byte {
-	holdersBlock := &models.HoldersBlock{
+func (p *Parser) GetEncodedHolders(enc encoding.Encoder) []byte {
+	holdersBlock := HoldersBlock{&models.HoldersBlock{
 		Block:     p.state.Block.Number.Uint64(),
 		Timestamp: int64(p.state.Block.Timestamp),
 		Chain:     p.state.Block.Network,
 		Holders:   p.state.Holders,
 		Notify:    p.state.Block.Notify,
-	}
-	b, err := msgpack.Marshal(holdersBlock)
+	}}
+	b, err := enc.Encode(holdersBlock)
 	if err != nil {
-		p.log.Error("PublishHolders: " + err.Error())
+		p.log.Error("Could not encode holders", zap.Error(err))
 		return nil
 	}
 	return b
@@ -136,26 +235,39 @@ func (p *Parser) DeleteHolders() {
 	p.state.Holders = nil
 }
 
+// GetTokenDecimals - static call of decimals(), error of node is classified and retried by pool
 func (p *Parser) GetTokenDecimals(address *tronApi.Address) (int32, bool) {
-	token := trc20.New(p.api, address)
-	return token.TryToGetDecimals(3)
+	var decimals int32
+	err := p.nodes.Call(p.ctx, node.Constant, "GetTokenDecimals", func(api *tronApi.API) (err error) {
+		decimals, err = api.GetTokenDecimals(address.ToHex())
+		return err
+	})
+	if err != nil {
+		p.log.Warn("GetTokenDecimals", zap.String("token", address.ToBase58()), zap.Error(err))
+	}
+	return decimals, err == nil
 }
 
-func New(api *tronApi.API,
+func New(nodes *node.Pool,
 	lists *integrations.TokenListsProvider,
 	pairsCache cache.PairCache,
 	converter *converters.FiatConverter,
 	abiHolder *abi.Holder,
-	swLists *integrations.SunswapProvider) *Parser {
+	swLists *integrations.SunswapProvider,
+	nodeURL string,
+	log *zap.Logger) *Parser {
 	return &Parser{
+		nodeURL:       nodeURL,
 		fiatConverter: converter,
-		api:           api,
-		log:           zap.L().Sugar(),
+		nodes:         nodes,
+		log:           log,
+		logs:          make(map[string]int),
 		failedTx:      []tronApi.Transaction{},
 		txMap:         sync.Map{},
 		tokenLists:    lists,
 		pairsCache:    pairsCache,
 		abiHolder:     abiHolder,
 		sunswapPairs:  swLists,
+		ctx:           context.Background(),
 	}
 }
@@ -1,9 +1,15 @@
 package parser
 
 import (
+	"reflect"
+	"testing"
+
 	"github.com/goccy/go-json"
+	models "github.com/kattana-io/models/pkg/storage"
+	"github.com/kattana-io/tron-blocks-parser/internal/encoding"
 	tronApi "github.com/kattana-io/tron-objects-api/pkg/api"
-	"testing"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 func Test_getMethodId(t *testing.T) {
@@ -267,3 +273,85 @@ func Test_hasContractCalls(t *testing.T) {
 		})
 	}
 }
+
+func Test_encodeEntity(t *testing.T) {
+	enc := encoding.JSON{}
+	tests := []struct {
+		name   string
+		events []int
+		batch  bool
+		want   int
+	}{
+		{name: "No events", events: nil, batch: false, want: 0},
+		{name: "Per event", events: []int{1, 2, 3}, batch: false, want: 3},
+		{name: "Batch", events: []int{1, 2, 3}, batch: true, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := encodeEntity(enc, nil, tt.events, tt.batch)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != tt.want {
+				t.Errorf("encodeEntity() messages = %v, want %v", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func Test_eventName(t *testing.T) {
+	tests := []struct {
+		methodID int
+		want     string
+	}{
+		{methodID: transferEvent, want: "transfer"},
+		{methodID: snapshotEvent, want: "snapshot"},
+		{methodID: 0x12345678, want: "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := eventName(tt.methodID); got != tt.want {
+				t.Errorf("eventName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Summary(t *testing.T) {
+	p := New(nil, nil, nil, nil, nil, nil, "", zap.NewNop())
+	p.state = CreateState(&models.Block{})
+	p.transactions = 3
+	p.logs["transfer"] = 2
+	p.logs["univ3_swap"] = 1
+	p.state.AddTrade(&models.PairSwap{}, Provenance{})
+	p.state.AddProcessHolder(&models.Holder{})
+	p.state.AddProcessHolder(&models.Holder{})
+
+	enc := zapcore.NewMapObjectEncoder()
+	if err := p.Summary().MarshalLogObject(enc); err != nil {
+		t.Fatalf("MarshalLogObject() error = %v", err)
+	}
+	want := map[string]any{
+		"transactions":     3,
+		"logs":             map[string]any{"transfer": 2, "univ3_swap": 1},
+		"pair_swaps":       1,
+		"direct_swaps":     0,
+		"liquidity_events": 0,
+		"new_pairs":        0,
+		"transfer_events":  0,
+		"holders":          2,
+		"prices":           0,
+	}
+	if !reflect.DeepEqual(enc.Fields, want) {
+		t.Errorf("Summary() = %v, want %v", enc.Fields, want)
+	}
+}
+
+func Test_Summary_emitted(t *testing.T) {
+	before := Summary{PairSwaps: 2, Holders: 1}
+	after := Summary{PairSwaps: 3, Holders: 1, Transfers: 2}
+	want := map[string]int{"pair_swaps": 1, "transfer_events": 2}
+	if got := after.emitted(before); !reflect.DeepEqual(got, want) {
+		t.Errorf("emitted() = %v, want %v", got, want)
+	}
+}
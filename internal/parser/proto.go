@@ -0,0 +1,229 @@
+package parser
+
+import (
+	"math/big"
+	"time"
+
+	models "github.com/kattana-io/models/pkg/storage"
+	"github.com/kattana-io/tron-blocks-parser/internal/converters"
+	"github.com/kattana-io/tron-blocks-parser/pkg/pb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+/**
+ * Conversion of published payloads to messages of pkg/pb for protobuf encoding,
+ * amounts and decimals are converted to strings without loss of precision
+ */
+
+// HoldersBlock - holders payload, embedded to keep msgpack and json layout of models.HoldersBlock
+type HoldersBlock struct {
+	*models.HoldersBlock
+}
+
+func (i *State) ToProto() proto.Message {
+	return &pb.ParsedBlock{
+		DirectSwaps:     convertAll(i.DirectSwaps, directSwapToProto),
+		PairSwaps:       convertAll(i.PairSwaps, pairSwapToProto),
+		LiquidityEvents: convertAll(i.Liquidities, liquidityToProto),
+		TransferEvents:  convertAll(i.Transfers, transferToProto),
+		NewPairs:        convertAll(i.Pairs, newPairToProto),
+		Holders:         convertAll(i.Holders, holderToProto),
+		Block:           blockToProto(i.Block),
+		Prices:          convertAll(i.Prices, tokenPriceToProto),
+	}
+}
+
+func (h HoldersBlock) ToProto() proto.Message {
+	return &pb.HoldersBlock{
+		Block:     h.Block,
+		Timestamp: h.Timestamp,
+		Chain:     h.Chain,
+		Holders:   convertAll(h.Holders, holderToProto),
+		Notify:    h.Notify,
+	}
+}
+
+func (e EntityEvent[T]) ToProto() proto.Message {
+	m := &pb.EntityEvent{Block: blockToProto(e.Block)}
+	switch event := any(e.Event).(type) {
+	case *PairSwap:
+		m.Event = &pb.EntityEvent_PairSwap{PairSwap: pairSwapToProto(event)}
+	case *DirectSwap:
+		m.Event = &pb.EntityEvent_DirectSwap{DirectSwap: directSwapToProto(event)}
+	case *LiquidityEvent:
+		m.Event = &pb.EntityEvent_LiquidityEvent{LiquidityEvent: liquidityToProto(event)}
+	case *models.NewPair:
+		m.Event = &pb.EntityEvent_NewPair{NewPair: newPairToProto(event)}
+	case *models.TransferEvent:
+		m.Event = &pb.EntityEvent_TransferEvent{TransferEvent: transferToProto(event)}
+	}
+	return m
+}
+
+func (b EntityBatch[T]) ToProto() proto.Message {
+	m := &pb.EntityBatch{Block: blockToProto(b.Block)}
+	switch events := any(b.Events).(type) {
+	case []*PairSwap:
+		m.PairSwaps = convertAll(events, pairSwapToProto)
+	case []*DirectSwap:
+		m.DirectSwaps = convertAll(events, directSwapToProto)
+	case []*LiquidityEvent:
+		m.LiquidityEvents = convertAll(events, liquidityToProto)
+	case []*models.NewPair:
+		m.NewPairs = convertAll(events, newPairToProto)
+	case []*models.TransferEvent:
+		m.TransferEvents = convertAll(events, transferToProto)
+	}
+	return m
+}
+
+func convertAll[T, M any](items []T, convert func(T) M) []M {
+	if len(items) == 0 {
+		return nil
+	}
+	result := make([]M, 0, len(items))
+	for _, item := range items {
+		result = append(result, convert(item))
+	}
+	return result
+}
+
+func blockToProto(b *models.Block) *pb.Block {
+	if b == nil {
+		return nil
+	}
+	m := &pb.Block{
+		Network:   b.Network,
+		Timestamp: b.Timestamp,
+		Node:      b.Node,
+		Notify:    b.Notify,
+	}
+	if b.Number != nil {
+		m.Number = b.Number.Uint64()
+	}
+	return m
+}
+
+func provenanceToProto(p converters.Provenance) *pb.Provenance {
+	return &pb.Provenance{Source: pb.Source(p.Source), Pair: p.Pair, Distance: p.Distance}
+}
+
+func pairSwapToProto(s *PairSwap) *pb.PairSwap {
+	return &pb.PairSwap{
+		Tx:               s.Tx,
+		Date:             timestampToProto(s.Date),
+		Chain:            s.Chain,
+		BlockNumber:      s.BlockNumber,
+		Pair:             s.Pair,
+		Amount0:          bigToString(s.Amount0),
+		Amount1:          bigToString(s.Amount1),
+		Buy:              s.Buy,
+		PriceA:           s.PriceA.String(),
+		PriceAUsd:        s.PriceAUSD.String(),
+		PriceB:           s.PriceB.String(),
+		PriceBUsd:        s.PriceBUSD.String(),
+		Bot:              s.Bot,
+		Wallet:           s.Wallet,
+		Order:            int64(s.Order),
+		ValueUsd:         s.ValueUSD.String(),
+		PriceAProvenance: provenanceToProto(s.PriceAProvenance),
+		PriceBProvenance: provenanceToProto(s.PriceBProvenance),
+		ValueProvenance:  provenanceToProto(s.ValueProvenance),
+	}
+}
+
+func directSwapToProto(s *DirectSwap) *pb.DirectSwap {
+	return &pb.DirectSwap{
+		Tx:               s.Tx,
+		Date:             timestampToProto(s.Date),
+		Chain:            s.Chain,
+		BlockNumber:      s.BlockNumber,
+		Protocol:         s.Protocol,
+		SrcToken:         s.SrcToken,
+		DstToken:         s.DstToken,
+		Amount0:          bigToString(s.Amount0),
+		Amount1:          bigToString(s.Amount1),
+		PriceA:           s.PriceA.String(),
+		PriceAUsd:        s.PriceAUSD.String(),
+		PriceB:           s.PriceB.String(),
+		PriceBUsd:        s.PriceBUSD.String(),
+		Wallet:           s.Wallet,
+		Order:            int64(s.Order),
+		ValueUsd:         s.ValueUSD.String(),
+		PriceAProvenance: provenanceToProto(s.PriceAProvenance),
+		PriceBProvenance: provenanceToProto(s.PriceBProvenance),
+		ValueProvenance:  provenanceToProto(s.ValueProvenance),
+	}
+}
+
+func liquidityToProto(l *LiquidityEvent) *pb.LiquidityEvent {
+	return &pb.LiquidityEvent{
+		BlockNumber:      l.BlockNumber,
+		Date:             timestampToProto(l.Date),
+		Tx:               l.Tx,
+		Pair:             l.Pair,
+		Chain:            l.Chain,
+		Klass:            l.Klass,
+		Wallet:           l.Wallet,
+		Order:            int64(l.Order),
+		Reserve0:         l.Reserve0,
+		Reserve1:         l.Reserve1,
+		PriceA:           l.PriceA.String(),
+		PriceAUsd:        l.PriceAUSD.String(),
+		PriceB:           l.PriceB.String(),
+		PriceBUsd:        l.PriceBUSD.String(),
+		ReserveUsd:       l.ReserveUSD.String(),
+		PriceAProvenance: provenanceToProto(l.PriceAProvenance),
+		PriceBProvenance: provenanceToProto(l.PriceBProvenance),
+		ValueProvenance:  provenanceToProto(l.ValueProvenance),
+	}
+}
+
+// transferToProto - TRON parser doesn't emit transfers, message has no fields yet
+func transferToProto(*models.TransferEvent) *pb.TransferEvent {
+	return &pb.TransferEvent{}
+}
+
+func newPairToProto(p *models.NewPair) *pb.NewPair {
+	return &pb.NewPair{
+		Factory:     p.Factory,
+		Pair:        p.Pair,
+		Klass:       p.Klass,
+		Network:     p.Network,
+		Node:        p.Node,
+		PoolCreated: p.PoolCreated,
+	}
+}
+
+func holderToProto(h *models.Holder) *pb.Holder {
+	return &pb.Holder{Token: h.Token, From: h.From, To: h.To, Tx: h.Tx}
+}
+
+func tokenPriceToProto(p *converters.TokenPrice) *pb.TokenPrice {
+	return &pb.TokenPrice{
+		Token:     p.Token,
+		Open:      p.Open.String(),
+		High:      p.High.String(),
+		Low:       p.Low.String(),
+		Close:     p.Close.String(),
+		Vwap:      p.VWAP.String(),
+		Volume:    p.Volume.String(),
+		VolumeUsd: p.VolumeUSD.String(),
+		Trades:    int64(p.Trades),
+	}
+}
+
+func timestampToProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func bigToString(v *big.Int) string {
+	if v == nil {
+		return ""
+	}
+	return v.String()
+}
@@ -0,0 +1,97 @@
+package parser
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/goccy/go-json"
+	models "github.com/kattana-io/models/pkg/storage"
+	"github.com/kattana-io/tron-blocks-parser/internal/converters"
+	"github.com/kattana-io/tron-blocks-parser/internal/encoding"
+	"github.com/kattana-io/tron-blocks-parser/pkg/pb"
+	"github.com/shopspring/decimal"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+func Test_ProtobufKeepsPrecision(t *testing.T) {
+	// uint256 amount and price with more digits than float64 holds
+	amount, _ := new(big.Int).SetString("115792089237316195423570985008687907853269984665640564039457584007913129639935", 10)
+	price := decimal.RequireFromString("0.000000123456789012345678901")
+	block := &models.Block{Network: "TRON", Number: big.NewInt(57000000), Timestamp: 1700000000}
+	swap := &PairSwap{
+		PairSwap:   &models.PairSwap{Pair: "TPair", Amount0: amount, Amount1: big.NewInt(1), PriceAUSD: price},
+		Provenance: Provenance{PriceAProvenance: converters.Provenance{Source: converters.SourceCheckpoint, Pair: "TRef", Distance: 3}},
+	}
+	state := CreateState(block)
+	state.AddTrade(swap.PairSwap, swap.Provenance)
+
+	tests := []struct {
+		name    string
+		payload any
+		decode  func(b []byte) (*pb.PairSwap, error)
+	}{
+		{name: "Parsed block", payload: state, decode: func(b []byte) (*pb.PairSwap, error) {
+			m := &pb.ParsedBlock{}
+			err := proto.Unmarshal(b, m)
+			return m.GetPairSwaps()[0], err
+		}},
+		{name: "Entity event", payload: EntityEvent[*PairSwap]{Block: block, Event: swap}, decode: func(b []byte) (*pb.PairSwap, error) {
+			m := &pb.EntityEvent{}
+			err := proto.Unmarshal(b, m)
+			return m.GetPairSwap(), err
+		}},
+		{name: "Entity batch", payload: EntityBatch[*PairSwap]{Block: block, Events: []*PairSwap{swap}}, decode: func(b []byte) (*pb.PairSwap, error) {
+			m := &pb.EntityBatch{}
+			err := proto.Unmarshal(b, m)
+			return m.GetPairSwaps()[0], err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := encoding.Protobuf{}.Encode(tt.payload)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := tt.decode(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.GetAmount0() != amount.String() {
+				t.Errorf("amount0 = %v, want %v", got.GetAmount0(), amount)
+			}
+			if got.GetPriceAUsd() != price.String() {
+				t.Errorf("price_a_usd = %v, want %v", got.GetPriceAUsd(), price)
+			}
+			if p := got.GetPriceAProvenance(); p.GetSource() != pb.Source_SOURCE_CHECKPOINT || p.GetPair() != "TRef" || p.GetDistance() != 3 {
+				t.Errorf("price_a_provenance = %v, want checkpoint of TRef 3 blocks behind", p)
+			}
+		})
+	}
+}
+
+func Test_HoldersBlockLayout(t *testing.T) {
+	holders := &models.HoldersBlock{Block: 57000000, Chain: "TRON", Holders: []*models.Holder{{Token: "TToken", To: "TWallet"}}}
+	tests := []struct {
+		name   string
+		encode func(v any) ([]byte, error)
+	}{
+		{name: "json", encode: json.Marshal},
+		{name: "msgpack", encode: msgpack.Marshal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := tt.encode(holders)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := tt.encode(HoldersBlock{holders})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("HoldersBlock is encoded as %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -2,18 +2,53 @@ package parser
 
 import (
 	models "github.com/kattana-io/models/pkg/storage"
+	"github.com/kattana-io/tron-blocks-parser/internal/converters"
 	"sync"
 	"time"
 )
 
+// SchemaVersion - version of encoded State and HoldersBlock, bump on breaking changes
+const SchemaVersion = 2
+
+// Provenance - origin of USD prices and USD value of event
+type Provenance struct {
+	PriceAProvenance converters.Provenance `json:"price_a_provenance"`
+	PriceBProvenance converters.Provenance `json:"price_b_provenance"`
+	ValueProvenance  converters.Provenance `json:"value_provenance"`
+}
+
+func newProvenance(conv converters.Conversion, value converters.Provenance) Provenance {
+	return Provenance{
+		PriceAProvenance: conv.OriginA,
+		PriceBProvenance: conv.OriginB,
+		ValueProvenance:  value,
+	}
+}
+
+type DirectSwap struct {
+	*models.DirectSwap
+	Provenance
+}
+
+type PairSwap struct {
+	*models.PairSwap
+	Provenance
+}
+
+type LiquidityEvent struct {
+	*models.LiquidityEvent
+	Provenance
+}
+
 type State struct {
-	DirectSwaps     []*models.DirectSwap     `json:"direct_swaps"`
-	PairSwaps       []*models.PairSwap       `json:"pair_swaps"`
-	Liquidities     []*models.LiquidityEvent `json:"liquidity_events"`
+	DirectSwaps     []*DirectSwap            `json:"direct_swaps"`
+	PairSwaps       []*PairSwap              `json:"pair_swaps"`
+	Liquidities     []*LiquidityEvent        `json:"liquidity_events"`
 	Transfers       []*models.TransferEvent  `json:"transfer_events"`
 	Pairs           []*models.NewPair        `json:"new_pairs"`
 	Holders         []*models.Holder         `json:"holders"`
 	Block           *models.Block            `json:"block"`
+	Prices          []*converters.TokenPrice `json:"prices"`
 	pairsLock       *sync.Mutex
 	tradesLock      *sync.Mutex
 	liquidityLock   *sync.Mutex
@@ -34,16 +69,16 @@ func CreateState(block *models.Block) *State {
 	}
 }
 
-func (i *State) AddTrade(trade *models.PairSwap) {
+func (i *State) AddTrade(trade *models.PairSwap, provenance Provenance) {
 	i.tradesLock.Lock()
 	defer i.tradesLock.Unlock()
-	i.PairSwaps = append(i.PairSwaps, trade)
+	i.PairSwaps = append(i.PairSwaps, &PairSwap{PairSwap: trade, Provenance: provenance})
 }
 
-func (i *State) AddLiquidity(liquidity *models.LiquidityEvent) {
+func (i *State) AddLiquidity(liquidity *models.LiquidityEvent, provenance Provenance) {
 	i.liquidityLock.Lock()
 	defer i.liquidityLock.Unlock()
-	i.Liquidities = append(i.Liquidities, liquidity)
+	i.Liquidities = append(i.Liquidities, &LiquidityEvent{LiquidityEvent: liquidity, Provenance: provenance})
 }
 
 func (i *State) AddTransferEvent(transfer *models.TransferEvent) {
@@ -66,10 +101,10 @@ func (i *State) RegisterNewPair(factory, pair, klass, network, node string, bloc
 	})
 }
 
-func (i *State) AddDirectSwap(m *models.DirectSwap) {
+func (i *State) AddDirectSwap(m *models.DirectSwap, provenance Provenance) {
 	i.directSwapsLock.Lock()
 	defer i.directSwapsLock.Unlock()
-	i.DirectSwaps = append(i.DirectSwaps, m)
+	i.DirectSwaps = append(i.DirectSwaps, &DirectSwap{DirectSwap: m, Provenance: provenance})
 }
 
 func (i *State) AddProcessHolder(h *models.Holder) {
@@ -0,0 +1,89 @@
+package parser
+
+import (
+	"sort"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Summary - counters of parsed block for the per block log line
+type Summary struct {
+	Transactions int
+	// Logs - processed transaction logs by event name
+	Logs        map[string]int
+	PairSwaps   int
+	DirectSwaps int
+	Liquidities int
+	Pairs       int
+	Transfers   int
+	Holders     int
+	Prices      int
+}
+
+// Summary - take counters before holders are deleted
+func (p *Parser) Summary() Summary {
+	s := Summary{
+		Transactions: p.transactions,
+		Logs:         p.logs,
+	}
+	if p.state == nil {
+		return s
+	}
+	s.PairSwaps = len(p.state.PairSwaps)
+	s.DirectSwaps = len(p.state.DirectSwaps)
+	s.Liquidities = len(p.state.Liquidities)
+	s.Pairs = len(p.state.Pairs)
+	s.Transfers = len(p.state.Transfers)
+	s.Holders = len(p.state.Holders)
+	s.Prices = len(p.state.Prices)
+	return s
+}
+
+// MarshalLogObject - log summary as nested object
+func (s Summary) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt("transactions", s.Transactions)
+	if err := enc.AddObject("logs", logCounts(s.Logs)); err != nil {
+		return err
+	}
+	enc.AddInt("pair_swaps", s.PairSwaps)
+	enc.AddInt("direct_swaps", s.DirectSwaps)
+	enc.AddInt("liquidity_events", s.Liquidities)
+	enc.AddInt("new_pairs", s.Pairs)
+	enc.AddInt("transfer_events", s.Transfers)
+	enc.AddInt("holders", s.Holders)
+	enc.AddInt("prices", s.Prices)
+	return nil
+}
+
+// logCounts - encode counters in stable order
+type logCounts map[string]int
+
+func (c logCounts) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	names := make([]string, 0, len(c))
+	for name := range c {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		enc.AddInt(name, c[name])
+	}
+	return nil
+}
+
+// emitted - entities added since previous summary
+func (s Summary) emitted(before Summary) map[string]int {
+	diff := map[string]int{}
+	for name, count := range map[string]int{
+		"pair_swaps":       s.PairSwaps - before.PairSwaps,
+		"direct_swaps":     s.DirectSwaps - before.DirectSwaps,
+		"liquidity_events": s.Liquidities - before.Liquidities,
+		"new_pairs":        s.Pairs - before.Pairs,
+		"transfer_events":  s.Transfers - before.Transfers,
+		"holders":          s.Holders - before.Holders,
+	} {
+		if count > 0 {
+			diff[name] = count
+		}
+	}
+	return diff
+}
@@ -0,0 +1,44 @@
+package retry
+
+import "time"
+
+/**
+ * Schedule - decides what RETRY worker does with a failed block without blocking blocks behind it:
+ * due blocks are parsed, early ones are requeued to the end of failed_blocks.
+ * When a pass over failed blocks went round without due ones, the worker waits for the earliest of them,
+ * at most maxIdle, so blocks which arrive meanwhile are not delayed for long
+ */
+type Schedule struct {
+	maxIdle time.Duration
+	// first - key of the first block requeued in current pass
+	first    string
+	earliest time.Time
+}
+
+func NewSchedule(maxIdle time.Duration) *Schedule {
+	return &Schedule{maxIdle: maxIdle}
+}
+
+// Next - how long to wait before handling block of key due at, and whether it should be parsed or requeued after that
+func (s *Schedule) Next(key string, at, now time.Time) (wait time.Duration, due bool) {
+	if s.first != "" && key == s.first {
+		wait = s.earliest.Sub(now)
+		if wait > s.maxIdle {
+			wait = s.maxIdle
+		}
+		if wait < 0 {
+			wait = 0
+		}
+		s.first = ""
+	}
+	if !now.Add(wait).Before(at) {
+		s.first = ""
+		return wait, true
+	}
+	if s.first == "" {
+		s.first, s.earliest = key, at
+	} else if at.Before(s.earliest) {
+		s.earliest = at
+	}
+	return wait, false
+}
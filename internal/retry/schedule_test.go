@@ -0,0 +1,62 @@
+package retry
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSchedule_Next(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	type call struct {
+		key string
+		at  time.Time
+		// elapsed - time passed since the first call
+		elapsed  time.Duration
+		wantWait time.Duration
+		wantDue  bool
+	}
+	tests := []struct {
+		name  string
+		calls []call
+	}{
+		{name: "Due block", calls: []call{
+			{key: "1", at: now.Add(-time.Second), wantDue: true},
+		}},
+		{name: "Early block is requeued", calls: []call{
+			{key: "1", at: now.Add(time.Hour)},
+			{key: "2", at: now, wantDue: true},
+		}},
+		{name: "Pass without due blocks waits for the earliest", calls: []call{
+			{key: "1", at: now.Add(time.Hour)},
+			{key: "2", at: now.Add(10 * time.Second)},
+			{key: "1", at: now.Add(time.Hour), wantWait: 10 * time.Second},
+			{key: "2", at: now.Add(10 * time.Second), elapsed: 10 * time.Second, wantDue: true},
+		}},
+		{name: "Wait is limited", calls: []call{
+			{key: "1", at: now.Add(time.Hour)},
+			{key: "1", at: now.Add(time.Hour), wantWait: 30 * time.Second},
+			{key: "1", at: now.Add(time.Hour), wantWait: 30 * time.Second},
+		}},
+		{name: "Block which becomes due after wait", calls: []call{
+			{key: "1", at: now.Add(5 * time.Second)},
+			{key: "1", at: now.Add(5 * time.Second), wantWait: 5 * time.Second, wantDue: true},
+		}},
+		{name: "Due block starts a new pass", calls: []call{
+			{key: "1", at: now.Add(time.Hour)},
+			{key: "2", at: now, wantDue: true},
+			{key: "1", at: now.Add(time.Hour)},
+			{key: "1", at: now.Add(time.Hour), wantWait: 30 * time.Second},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSchedule(30 * time.Second)
+			for i, c := range tt.calls {
+				wait, due := s.Next(c.key, c.at, now.Add(c.elapsed))
+				if wait != c.wantWait || due != c.wantDue {
+					t.Errorf("call %d: Next(%v) = %v, %v, want %v, %v", i, c.key, wait, due, c.wantWait, c.wantDue)
+				}
+			}
+		})
+	}
+}
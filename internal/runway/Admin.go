@@ -0,0 +1,63 @@
+package runway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-json"
+)
+
+/**
+ * Admin endpoints for support, served on a separate listener which isn't exposed with metrics and probes
+ */
+
+const parsePath = "/parse/"
+
+// ParseFunc - parse block by number and publish it when requested, returns encoded State
+type ParseFunc func(ctx context.Context, number uint64, publish bool) ([]byte, error)
+
+// HandleParse - register POST /parse/{number}?publish=true
+func (r *Runway) HandleParse(parse ParseFunc) {
+	r.adminMux.HandleFunc(parsePath, parseHandler(parse))
+}
+
+func parseHandler(parse ParseFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeError(w, http.StatusMethodNotAllowed, errors.New("only POST is allowed"))
+			return
+		}
+		raw := strings.TrimPrefix(r.URL.Path, parsePath)
+		number, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil || number == 0 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid block number %q", raw))
+			return
+		}
+		publish := false
+		if value := r.URL.Query().Get("publish"); value != "" {
+			if publish, err = strconv.ParseBool(value); err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Errorf("invalid publish flag %q", value))
+				return
+			}
+		}
+
+		body, err := parse(r.Context(), number, publish)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
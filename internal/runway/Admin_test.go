@@ -0,0 +1,47 @@
+package runway
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdmin_Parse(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		parseErr    error
+		want        int
+		wantNumber  uint64
+		wantPublish bool
+	}{
+		{name: "Dry run", method: http.MethodPost, target: "/parse/57000000", want: http.StatusOK, wantNumber: 57000000},
+		{name: "Publish", method: http.MethodPost, target: "/parse/57000000?publish=true", want: http.StatusOK, wantNumber: 57000000, wantPublish: true},
+		{name: "Wrong method", method: http.MethodGet, target: "/parse/57000000", want: http.StatusMethodNotAllowed},
+		{name: "Invalid number", method: http.MethodPost, target: "/parse/latest", want: http.StatusBadRequest},
+		{name: "Zero block", method: http.MethodPost, target: "/parse/0", want: http.StatusBadRequest},
+		{name: "Invalid flag", method: http.MethodPost, target: "/parse/1?publish=maybe", want: http.StatusBadRequest},
+		{name: "Parse failed", method: http.MethodPost, target: "/parse/1", parseErr: errors.New("node is down"), want: http.StatusInternalServerError, wantNumber: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotNumber uint64
+			var gotPublish bool
+			handler := parseHandler(func(_ context.Context, number uint64, publish bool) ([]byte, error) {
+				gotNumber, gotPublish = number, publish
+				return []byte(`{}`), tt.parseErr
+			})
+			rec := httptest.NewRecorder()
+			handler(rec, httptest.NewRequest(tt.method, tt.target, nil))
+			if rec.Code != tt.want {
+				t.Errorf("parse code = %v, want %v", rec.Code, tt.want)
+			}
+			if gotNumber != tt.wantNumber || gotPublish != tt.wantPublish {
+				t.Errorf("parse(%v, %v), want parse(%v, %v)", gotNumber, gotPublish, tt.wantNumber, tt.wantPublish)
+			}
+		})
+	}
+}
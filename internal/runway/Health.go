@@ -0,0 +1,143 @@
+package runway
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/kattana-io/tron-blocks-parser/internal/config"
+)
+
+// Check - readiness check of dependency, nil error means dependency is available
+type Check func(ctx context.Context) error
+
+// Backlog - number of messages waiting for main loop
+type Backlog func(ctx context.Context) (int64, error)
+
+/**
+ * Health - liveness and readiness probes
+ * Liveness fails when main loop didn't fetch or commit a message for StaleAfter while it is busy with a message
+ * or while messages are waiting for it (e.g. consumer hung after a rebalance), idle loop of idle topic is alive.
+ * Readiness runs checks of dependencies
+ */
+type Health struct {
+	staleAfter   time.Duration
+	checkTimeout time.Duration
+	lastTick     atomic.Int64
+	busy         atomic.Bool
+	backlog      atomic.Pointer[Backlog]
+	checksMutex  sync.RWMutex
+	names        []string
+	checks       map[string]Check
+}
+
+func NewHealth(cfg config.Health) *Health {
+	h := &Health{
+		staleAfter:   cfg.StaleAfter,
+		checkTimeout: cfg.CheckTimeout,
+		checks:       make(map[string]Check),
+	}
+	h.Tick()
+	return h
+}
+
+// Tick - mark progress of main loop
+func (h *Health) Tick() {
+	h.lastTick.Store(time.Now().UnixNano())
+}
+
+// Busy - main loop took a message, staleness is measured from now until Idle
+func (h *Health) Busy() {
+	h.Tick()
+	h.busy.Store(true)
+}
+
+// Idle - main loop is done with a message and waits for the next one, which may take forever on idle topic
+func (h *Health) Idle() {
+	h.Tick()
+	h.busy.Store(false)
+}
+
+// SetBacklog - register backlog of main loop, idle loop is stale when backlog is not empty
+func (h *Health) SetBacklog(backlog Backlog) {
+	h.backlog.Store(&backlog)
+}
+
+// AddCheck - register readiness check
+func (h *Health) AddCheck(name string, check Check) {
+	h.checksMutex.Lock()
+	defer h.checksMutex.Unlock()
+	if _, ok := h.checks[name]; !ok {
+		h.names = append(h.names, name)
+	}
+	h.checks[name] = check
+}
+
+// Alive - main loop ticked within StaleAfter or is idle without backlog, always true when StaleAfter is 0.
+// Backlog which could not be read doesn't fail liveness, unavailable Kafka is reported by readiness
+func (h *Health) Alive(ctx context.Context) (bool, time.Duration, int64) {
+	since := time.Since(time.Unix(0, h.lastTick.Load()))
+	switch {
+	case h.staleAfter <= 0 || since < h.staleAfter:
+		return true, since, 0
+	case h.busy.Load():
+		return false, since, 0
+	}
+	backlog := h.backlog.Load()
+	if backlog == nil {
+		return true, since, 0
+	}
+	checkCtx, cancel := context.WithTimeout(ctx, h.checkTimeout)
+	defer cancel()
+	lag, err := (*backlog)(checkCtx)
+	if err != nil {
+		return true, since, 0
+	}
+	return lag == 0, since, lag
+}
+
+// Ready - run all checks, result contains "ok" or error of every check
+func (h *Health) Ready(ctx context.Context) (bool, map[string]string) {
+	h.checksMutex.RLock()
+	defer h.checksMutex.RUnlock()
+
+	ready := true
+	result := make(map[string]string, len(h.names))
+	for _, name := range h.names {
+		checkCtx, cancel := context.WithTimeout(ctx, h.checkTimeout)
+		err := h.checks[name](checkCtx)
+		cancel()
+		if err != nil {
+			ready = false
+			result[name] = err.Error()
+			continue
+		}
+		result[name] = "ok"
+	}
+	return ready, result
+}
+
+func (h *Health) livenessHandler(w http.ResponseWriter, r *http.Request) {
+	alive, since, lag := h.Alive(r.Context())
+	writeProbe(w, alive, map[string]string{
+		"last_tick": since.Round(time.Millisecond).String(),
+		"lag":       strconv.FormatInt(lag, 10),
+	})
+}
+
+func (h *Health) readinessHandler(w http.ResponseWriter, r *http.Request) {
+	ready, result := h.Ready(r.Context())
+	writeProbe(w, ready, result)
+}
+
+func writeProbe(w http.ResponseWriter, ok bool, body map[string]string) {
+	w.Header().Set("Content-Type", "application/json")
+	if !ok {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(body)
+}
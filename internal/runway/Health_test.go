@@ -0,0 +1,67 @@
+package runway
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kattana-io/tron-blocks-parser/internal/config"
+)
+
+func TestHealth_Liveness(t *testing.T) {
+	lag := func(n int64, err error) Backlog {
+		return func(context.Context) (int64, error) { return n, err }
+	}
+	tests := []struct {
+		name       string
+		staleAfter time.Duration
+		lastTick   time.Time
+		busy       bool
+		backlog    Backlog
+		want       int
+	}{
+		{name: "Recent tick", staleAfter: time.Minute, lastTick: time.Now(), busy: true, want: http.StatusOK},
+		{name: "Stale loop", staleAfter: time.Minute, lastTick: time.Now().Add(-time.Hour), busy: true, want: http.StatusServiceUnavailable},
+		{name: "Idle topic", staleAfter: time.Minute, lastTick: time.Now().Add(-time.Hour), backlog: lag(0, nil), want: http.StatusOK},
+		{name: "Idle loop with lag", staleAfter: time.Minute, lastTick: time.Now().Add(-time.Hour), backlog: lag(5, nil), want: http.StatusServiceUnavailable},
+		{name: "Idle loop with recent fetch and lag", staleAfter: time.Minute, lastTick: time.Now(), backlog: lag(5, nil), want: http.StatusOK},
+		{name: "Unknown lag", staleAfter: time.Minute, lastTick: time.Now().Add(-time.Hour), backlog: lag(0, errors.New("unreachable")), want: http.StatusOK},
+		{name: "Disabled", staleAfter: 0, lastTick: time.Now().Add(-time.Hour), busy: true, want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHealth(config.Health{StaleAfter: tt.staleAfter, CheckTimeout: time.Second})
+			h.lastTick.Store(tt.lastTick.UnixNano())
+			h.busy.Store(tt.busy)
+			if tt.backlog != nil {
+				h.SetBacklog(tt.backlog)
+			}
+			rec := httptest.NewRecorder()
+			h.livenessHandler(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+			if rec.Code != tt.want {
+				t.Errorf("liveness code = %v, want %v", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestHealth_Readiness(t *testing.T) {
+	h := NewHealth(config.Health{StaleAfter: time.Minute, CheckTimeout: time.Second})
+	h.AddCheck("redis", func(ctx context.Context) error { return nil })
+
+	rec := httptest.NewRecorder()
+	h.readinessHandler(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("readiness code = %v, want %v", rec.Code, http.StatusOK)
+	}
+
+	h.AddCheck("kafka", func(ctx context.Context) error { return errors.New("unreachable") })
+	rec = httptest.NewRecorder()
+	h.readinessHandler(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("readiness code = %v, want %v", rec.Code, http.StatusServiceUnavailable)
+	}
+}
@@ -1,38 +1,88 @@
 package runway
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
 	"github.com/go-redis/redis/v8"
+	"github.com/kattana-io/tron-blocks-parser/internal/config"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
-	"os"
 )
 
+// readHeaderTimeout - protects http server from slow clients
+const readHeaderTimeout = 5 * time.Second
+
 type Runway struct {
 	logger *zap.Logger
 	redis  *redis.Client
+	health *Health
+	mux    *http.ServeMux
+	server *http.Server
+	// adminMux - handlers which change state, admin is nil when they are disabled
+	adminMux *http.ServeMux
+	admin    *http.Server
 }
 
-func Create() *Runway {
-	logger := zap.Must(zap.NewProduction())
+func Create(redisConfig config.Redis, httpConfig config.HTTP, logLevel string) *Runway {
+	// Invalid level is reported by config validation, start with info to be able to log it
+	level, err := zap.ParseAtomicLevel(logLevel)
+	if err != nil {
+		level = zap.NewAtomicLevel()
+	}
+	logCfg := zap.NewProductionConfig()
+	logCfg.Level = level
+	logger := zap.Must(logCfg.Build())
 	zap.ReplaceGlobals(logger)
 
-	return &Runway{
+	rdb := ConnectRedis(redisConfig)
+	health := NewHealth(httpConfig.Health)
+	health.AddCheck("redis", func(ctx context.Context) error {
+		return rdb.Ping(ctx).Err()
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthz", health.livenessHandler)
+	mux.HandleFunc("/readyz", health.readinessHandler)
+
+	adminMux := http.NewServeMux()
+	// GET returns current level, PUT {"level":"debug"} changes it
+	adminMux.Handle("/log/level", level)
+
+	r := &Runway{
 		logger: logger,
-		redis:  ConnectRedis(),
+		redis:  rdb,
+		health: health,
+		mux:    mux,
+		server: &http.Server{
+			Addr:              httpConfig.Addr,
+			Handler:           mux,
+			ReadHeaderTimeout: readHeaderTimeout,
+		},
+		adminMux: adminMux,
 	}
+	if httpConfig.AdminAddr != "" {
+		r.admin = &http.Server{
+			Addr:              httpConfig.AdminAddr,
+			Handler:           adminMux,
+			ReadHeaderTimeout: readHeaderTimeout,
+		}
+	}
+	return r
 }
 
-func ConnectRedis() *redis.Client {
-	Addr := os.Getenv("REDIS_ADDR")
-	Password := os.Getenv("REDIS_PASSWORD")
-
+func ConnectRedis(redisConfig config.Redis) *redis.Client {
 	cfg := &redis.Options{
-		Addr: Addr,
-		DB:   0,
+		Addr: redisConfig.Addr,
+		DB:   redisConfig.DB,
 	}
 
 	// we may skip password in development
-	if len(Password) > 0 {
-		cfg.Password = Password
+	if len(redisConfig.Password) > 0 {
+		cfg.Password = redisConfig.Password
 	}
 
 	rdb := redis.NewClient(cfg)
@@ -46,3 +96,36 @@ func (r *Runway) Logger() *zap.Logger {
 func (r *Runway) Redis() *redis.Client {
 	return r.redis
 }
+
+func (r *Runway) Health() *Health {
+	return r.health
+}
+
+// Handle - register handler on http server
+func (r *Runway) Handle(pattern string, handler http.Handler) {
+	r.mux.Handle(pattern, handler)
+}
+
+// Serve - start http and admin servers in background
+func (r *Runway) Serve() {
+	go r.listen("http", r.server)
+	if r.admin != nil {
+		go r.listen("admin", r.admin)
+	}
+}
+
+func (r *Runway) listen(name string, server *http.Server) {
+	r.logger.Info("Start "+name+" server", zap.String("addr", server.Addr))
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		r.logger.Error(name+" server stopped", zap.Error(err))
+	}
+}
+
+// Shutdown - stop http servers and close redis
+func (r *Runway) Shutdown(ctx context.Context) error {
+	var adminErr error
+	if r.admin != nil {
+		adminErr = r.admin.Shutdown(ctx)
+	}
+	return errors.Join(r.server.Shutdown(ctx), adminErr, r.redis.Close())
+}
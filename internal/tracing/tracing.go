@@ -0,0 +1,107 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/kattana-io/tron-blocks-parser/internal/config"
+	"github.com/kattana-io/tron-blocks-parser/internal/models"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+/**
+ * OpenTelemetry tracing of block parse stages
+ * OTLP exporter is configured by standard OTEL_EXPORTER_OTLP_* env variables
+ */
+
+const (
+	serviceName = "tron-blocks-parser"
+	tracerName  = "github.com/kattana-io/tron-blocks-parser"
+)
+
+// Span attributes
+const (
+	BlockNumber  = attribute.Key("block.number")
+	BlockNetwork = attribute.Key("block.network")
+	Attempt      = attribute.Key("block.attempt")
+	Mode         = attribute.Key("parser.mode")
+	TxHash       = attribute.Key("tx.hash")
+	Pair         = attribute.Key("pair.address")
+	PairKlass    = attribute.Key("pair.klass")
+	Token        = attribute.Key("token.address")
+	Topic        = attribute.Key("messaging.destination.name")
+	Messages     = attribute.Key("messaging.batch.message_count")
+)
+
+// Setup - register global tracer provider, returned function flushes spans on shutdown
+func Setup(ctx context.Context, cfg config.Tracing, version string) (func(context.Context) error, error) {
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch cfg.Exporter {
+	case models.TracingNone:
+		return func(context.Context) error { return nil }, nil
+	case models.TracingStdout:
+		// stdout of parse command is JSONL of blocks, spans go along with logs
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stderr))
+	case models.TracingOTLP:
+		exporter, err = otlptracehttp.New(ctx)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create %s exporter: %w", cfg.Exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(version),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return provider.Shutdown, nil
+}
+
+// Start - start span with tracer of parser
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End - record error of stage and end span
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// CorrelationID - id of trace to link logs with spans, random id when tracing is disabled
+func CorrelationID(ctx context.Context) string {
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		return sc.TraceID().String()
+	}
+	var id trace.TraceID
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
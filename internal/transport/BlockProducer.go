@@ -0,0 +1,49 @@
+package transport
+
+import (
+	"context"
+
+	"github.com/goccy/go-json"
+	commonModels "github.com/kattana-io/models/pkg/storage"
+	"github.com/kattana-io/tron-blocks-parser/internal/config"
+	"github.com/kattana-io/tron-blocks-parser/internal/models"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// BlockSchemaVersion - version of input block message, the same as written by blocks notifier
+const BlockSchemaVersion = 1
+
+// BlockProducer - writes input blocks for parser workers, used by backfill of history topic
+type BlockProducer struct {
+	*Publisher
+}
+
+func NewBlockProducer(topic string,
+	address []string,
+	strategy models.KeyStrategy,
+	producer config.Producer,
+	log *zap.Logger) *BlockProducer {
+	return &BlockProducer{
+		Publisher: NewPublisher(topic, address, strategy, models.HISTORY, producer, log),
+	}
+}
+
+// Produce - write blocks in one batch, input blocks are always JSON
+func (p *BlockProducer) Produce(ctx context.Context, blocks []commonModels.Block) error {
+	msgs := make([]kafka.Message, 0, len(blocks))
+	for _, block := range blocks {
+		value, err := json.Marshal(block)
+		if err != nil {
+			return err
+		}
+		meta := Meta{
+			Network:  block.Network,
+			Number:   block.Number.Uint64(),
+			Schema:   BlockSchemaVersion,
+			Encoding: models.JSON,
+		}
+		msgs = append(msgs, p.messages(meta, value)...)
+	}
+	return p.write(ctx, msgs...)
+}
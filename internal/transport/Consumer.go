@@ -0,0 +1,61 @@
+package transport
+
+import (
+	"context"
+
+	"github.com/kattana-io/tron-blocks-parser/internal/config"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// Consumer - reads blocks one by one, offsets are committed explicitly
+// after the block has been published, so blocks are delivered at least once
+type Consumer struct {
+	log *zap.Logger
+	r   *kafka.Reader
+	// pending - rolled back message, returned by the next Fetch
+	pending *kafka.Message
+}
+
+func NewConsumer(topic, groupID string, address []string, reader config.Reader, log *zap.Logger) *Consumer {
+	r := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:  address,
+		Topic:    topic,
+		GroupID:  groupID,
+		MinBytes: reader.MinBytes,
+		MaxBytes: reader.MaxBytes,
+		MaxWait:  reader.MaxWait,
+	})
+
+	return &Consumer{
+		log: log,
+		r:   r,
+	}
+}
+
+// Fetch - read next message without committing it
+func (c *Consumer) Fetch(ctx context.Context) (kafka.Message, error) {
+	if c.pending != nil {
+		msg := *c.pending
+		c.pending = nil
+		return msg, nil
+	}
+	return c.r.FetchMessage(ctx)
+}
+
+// Commit - mark message as processed
+func (c *Consumer) Commit(ctx context.Context, msg kafka.Message) error {
+	return c.r.CommitMessages(ctx, msg)
+}
+
+// Rollback - fetch message again, reader doesn't rewind, so it is kept in memory
+func (c *Consumer) Rollback(_ context.Context, msg kafka.Message) error {
+	c.pending = &msg
+	return nil
+}
+
+func (c *Consumer) Close() {
+	if err := c.r.Close(); err != nil {
+		c.log.Error("failed to close reader", zap.Error(err))
+	}
+}
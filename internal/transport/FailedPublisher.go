@@ -0,0 +1,47 @@
+package transport
+
+import (
+	"context"
+	"time"
+
+	"github.com/goccy/go-json"
+	commonModels "github.com/kattana-io/models/pkg/storage"
+	"github.com/kattana-io/tron-blocks-parser/internal/models"
+)
+
+// FailedPublisher - long-lived writer which returns blocks to failed_blocks wrapped into envelope,
+// publisher of TxSession writes them in transaction of the consumed block
+type FailedPublisher struct {
+	BlockPublisher
+	version string
+}
+
+func NewFailedPublisher(publisher BlockPublisher, version string) *FailedPublisher {
+	return &FailedPublisher{
+		BlockPublisher: publisher,
+		version:        version,
+	}
+}
+
+// PublishFailedBlock - return block to failed_blocks with mode which failed, the reason of failure and attempt number
+func (p *FailedPublisher) PublishFailedBlock(ctx context.Context, block commonModels.Block, mode models.Mode, reason string, attempt int) error {
+	Value, err := json.Marshal(models.FailedBlock{
+		Block:         block,
+		Mode:          mode,
+		Reason:        reason,
+		Attempt:       attempt,
+		ParserVersion: p.version,
+		Timestamp:     time.Now().Unix(),
+	})
+	if err != nil {
+		return err
+	}
+	meta := Meta{
+		Network: block.Network,
+		Number:  block.Number.Uint64(),
+		Schema:  models.FailedBlockSchemaVersion,
+		// Envelope is always JSON, it is consumed back by RETRY mode
+		Encoding: models.JSON,
+	}
+	return p.PublishBlock(ctx, meta, Value)
+}
@@ -0,0 +1,44 @@
+package transport
+
+import (
+	"context"
+
+	"github.com/kattana-io/tron-blocks-parser/internal/models"
+)
+
+// FanOutPublisher - publishes parsed entities to per-entity topics, all messages of block share the same key
+type FanOutPublisher struct {
+	publishers map[models.Entity]BlockPublisher
+}
+
+// NewFanOutPublisher - create is called once per topic to build its writer
+func NewFanOutPublisher(topics map[models.Entity]string, create func(topic string) BlockPublisher) *FanOutPublisher {
+	publishers := make(map[models.Entity]BlockPublisher, len(topics))
+	for entity, topic := range topics {
+		publishers[entity] = create(topic)
+	}
+	return &FanOutPublisher{publishers: publishers}
+}
+
+// Publish - write encoded entities of block, events of each entity are written in one batch
+func (f *FanOutPublisher) Publish(ctx context.Context, meta Meta, entities map[models.Entity][][]byte) error {
+	for entity, payloads := range entities {
+		p, ok := f.publishers[entity]
+		if !ok || len(payloads) == 0 {
+			continue
+		}
+		if err := p.PublishBlock(ctx, meta, payloads...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Publishers - underlying writers, used to close them on shutdown
+func (f *FanOutPublisher) Publishers() []BlockPublisher {
+	publishers := make([]BlockPublisher, 0, len(f.publishers))
+	for _, p := range f.publishers {
+		publishers = append(publishers, p)
+	}
+	return publishers
+}
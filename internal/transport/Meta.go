@@ -0,0 +1,88 @@
+package transport
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kattana-io/tron-blocks-parser/internal/models"
+	"github.com/kattana-io/tron-blocks-parser/pkg/chunks"
+	"github.com/segmentio/kafka-go"
+)
+
+// Meta - block information used for message key and headers
+type Meta struct {
+	Network string
+	Number  uint64
+	Schema  int
+	// Encoding - format of payload
+	Encoding models.Encoding
+}
+
+// Headers attached to every published message
+const (
+	HeaderBlockNumber   = "block_number"
+	HeaderNetwork       = "network"
+	HeaderMode          = "mode"
+	HeaderSchemaVersion = "schema_version"
+	HeaderEncoding      = "encoding"
+)
+
+func messageKey(strategy models.KeyStrategy, meta Meta) []byte {
+	switch strategy {
+	case models.KeyByBlock:
+		return []byte(fmt.Sprintf("%s:%d", meta.Network, meta.Number))
+	case models.KeyByNetwork:
+		return []byte(meta.Network)
+	default:
+		return nil
+	}
+}
+
+// balancer - keyed messages are hashed, so chunks of one payload share partition even without key strategy.
+// Messages without key are distributed round-robin
+func balancer() kafka.Balancer {
+	return &kafka.Hash{}
+}
+
+func headers(meta Meta, mode models.Mode) []kafka.Header {
+	return []kafka.Header{
+		{Key: HeaderBlockNumber, Value: []byte(strconv.FormatUint(meta.Number, 10))},
+		{Key: HeaderNetwork, Value: []byte(meta.Network)},
+		{Key: HeaderMode, Value: []byte(mode)},
+		{Key: HeaderSchemaVersion, Value: []byte(strconv.Itoa(meta.Schema))},
+		{Key: HeaderEncoding, Value: []byte(meta.Encoding)},
+	}
+}
+
+// chunkHeadroom - space for headers and batch overhead on top of the largest chunk
+const chunkHeadroom = 64 * 1024
+
+// part - payload or its chunk with key and headers
+type part struct {
+	key     []byte
+	value   []byte
+	headers []kafka.Header
+}
+
+// split - payloads above limit are split into chunks, every chunk carries block and chunk headers.
+// Chunks without key are keyed by chunk id to land on one partition
+func split(meta Meta, mode models.Mode, key, payload []byte, limit int) []part {
+	base := headers(meta, mode)
+	if limit <= 0 || len(payload) <= limit {
+		return []part{{key: key, value: payload, headers: base}}
+	}
+	id := chunks.ID(meta.Network, meta.Number, payload)
+	if key == nil {
+		key = []byte(id)
+	}
+	parts := chunks.Split(id, payload, limit)
+	result := make([]part, 0, len(parts))
+	for _, c := range parts {
+		h := append(make([]kafka.Header, 0, len(base)+3), base...)
+		for _, ch := range c.Headers() {
+			h = append(h, kafka.Header{Key: ch.Key, Value: ch.Value})
+		}
+		result = append(result, part{key: key, value: c.Data, headers: h})
+	}
+	return result
+}
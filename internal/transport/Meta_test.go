@@ -0,0 +1,89 @@
+package transport
+
+import (
+	"testing"
+
+	"github.com/kattana-io/tron-blocks-parser/internal/models"
+	"github.com/kattana-io/tron-blocks-parser/pkg/chunks"
+)
+
+func TestMessageKey(t *testing.T) {
+	meta := Meta{Network: "TRON", Number: 42, Schema: 1}
+	tests := []struct {
+		name     string
+		strategy models.KeyStrategy
+		want     string
+	}{
+		{name: "By block", strategy: models.KeyByBlock, want: "TRON:42"},
+		{name: "By network", strategy: models.KeyByNetwork, want: "TRON"},
+		{name: "None", strategy: models.KeyNone, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(messageKey(tt.strategy, meta)); got != tt.want {
+				t.Errorf("messageKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeaders(t *testing.T) {
+	got := headers(Meta{Network: "TRON", Number: 42, Schema: 2, Encoding: models.JSON}, models.LIVE)
+	want := map[string]string{
+		HeaderBlockNumber:   "42",
+		HeaderNetwork:       "TRON",
+		HeaderMode:          string(models.LIVE),
+		HeaderSchemaVersion: "2",
+		HeaderEncoding:      string(models.JSON),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("headers() len = %d, want %d", len(got), len(want))
+	}
+	for _, h := range got {
+		if want[h.Key] != string(h.Value) {
+			t.Errorf("header %s = %v, want %v", h.Key, string(h.Value), want[h.Key])
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	meta := Meta{Network: "TRON", Number: 42, Schema: 2, Encoding: models.MsgPack}
+	tests := []struct {
+		name     string
+		strategy models.KeyStrategy
+		size     int
+		limit    int
+		parts    int
+		headers  int
+		// chunkKey - parts are keyed by chunk id
+		chunkKey bool
+	}{
+		{name: "Below limit", strategy: models.KeyByBlock, size: 100, limit: 100, parts: 1, headers: 5},
+		{name: "Chunked", strategy: models.KeyByBlock, size: 250, limit: 100, parts: 3, headers: 8},
+		{name: "No limit", strategy: models.KeyByBlock, size: 250, limit: 0, parts: 1, headers: 5},
+		{name: "Without key below limit", strategy: models.KeyNone, size: 100, limit: 100, parts: 1, headers: 5},
+		{name: "Chunked without key", strategy: models.KeyNone, size: 250, limit: 100, parts: 3, headers: 8, chunkKey: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := make([]byte, tt.size)
+			key := messageKey(tt.strategy, meta)
+			got := split(meta, models.LIVE, key, payload, tt.limit)
+			if len(got) != tt.parts {
+				t.Fatalf("split() parts = %d, want %d", len(got), tt.parts)
+			}
+			want := string(key)
+			if tt.chunkKey {
+				want = chunks.ID(meta.Network, meta.Number, payload)
+			}
+			for _, p := range got {
+				if len(p.headers) != tt.headers {
+					t.Errorf("split() headers = %d, want %d", len(p.headers), tt.headers)
+				}
+				if string(p.key) != want {
+					t.Errorf("split() key = %v, want %v", string(p.key), want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package transport
+
+import (
+	"context"
+	"errors"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Partitions - number of partitions of topic
+func Partitions(ctx context.Context, brokers []string, topic string) (int, error) {
+	var errs []error
+	for _, broker := range brokers {
+		conn, err := kafka.DialContext(ctx, "tcp", broker)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		partitions, err := conn.ReadPartitions(topic)
+		_ = conn.Close()
+		if err != nil {
+			return 0, err
+		}
+		return len(partitions), nil
+	}
+	return 0, errors.Join(errs...)
+}
+
+// GroupLag - number of committed messages of topic which consumer group didn't consume yet,
+// offsets are read from group coordinator, so it also fails when coordinator is unavailable
+func GroupLag(ctx context.Context, brokers []string, groupID, topic string) (int64, error) {
+	partitions, err := Partitions(ctx, brokers, topic)
+	if err != nil {
+		return 0, err
+	}
+	ids := make([]int, 0, partitions)
+	requests := make([]kafka.OffsetRequest, 0, 2*partitions)
+	for partition := 0; partition < partitions; partition++ {
+		ids = append(ids, partition)
+		requests = append(requests, kafka.FirstOffsetOf(partition), kafka.LastOffsetOf(partition))
+	}
+
+	client := &kafka.Client{Addr: kafka.TCP(brokers...)}
+	committed, err := client.OffsetFetch(ctx, &kafka.OffsetFetchRequest{
+		GroupID: groupID,
+		Topics:  map[string][]int{topic: ids},
+	})
+	if err != nil {
+		return 0, err
+	}
+	if committed.Error != nil {
+		return 0, committed.Error
+	}
+	offsets, err := client.ListOffsets(ctx, &kafka.ListOffsetsRequest{
+		Topics:         map[string][]kafka.OffsetRequest{topic: requests},
+		IsolationLevel: kafka.ReadCommitted,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	bounds := make(map[int]kafka.PartitionOffsets, partitions)
+	for _, p := range offsets.Topics[topic] {
+		if p.Error != nil {
+			return 0, p.Error
+		}
+		bounds[p.Partition] = p
+	}
+	var lag int64
+	for _, p := range committed.Topics[topic] {
+		if p.Error != nil {
+			return 0, p.Error
+		}
+		// group without committed offset starts from the first one
+		offset := p.CommittedOffset
+		if first := bounds[p.Partition].FirstOffset; offset < first {
+			offset = first
+		}
+		if last := bounds[p.Partition].LastOffset; last > offset {
+			lag += last - offset
+		}
+	}
+	return lag, nil
+}
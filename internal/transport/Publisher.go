@@ -3,62 +3,133 @@ package transport
 import (
 	"context"
 	"fmt"
+	"time"
 
-	"github.com/goccy/go-json"
-	models "github.com/kattana-io/models/pkg/storage"
+	"github.com/kattana-io/tron-blocks-parser/internal/config"
+	"github.com/kattana-io/tron-blocks-parser/internal/metrics"
+	"github.com/kattana-io/tron-blocks-parser/internal/models"
+	"github.com/kattana-io/tron-blocks-parser/internal/tracing"
 	"github.com/segmentio/kafka-go"
 	"go.uber.org/zap"
 )
 
 type Publisher struct {
-	log     *zap.Logger
-	w       *kafka.Writer
-	address []string
+	log             *zap.Logger
+	w               *kafka.Writer
+	strategy        models.KeyStrategy
+	mode            models.Mode
+	maxMessageBytes int
 }
 
-func NewPublisher(topic string, address []string, log *zap.Logger) *Publisher {
+const (
+	// batchTimeout - writes are synchronous, so don't wait for batch to fill up
+	batchTimeout = 10 * time.Millisecond
+	// retry policy of writes, backoff doubles after each failed attempt
+	maxAttempts    = 5
+	initialBackoff = 200 * time.Millisecond
+	maxBackoff     = 10 * time.Second
+)
+
+// NewPublisher - writes are synchronous, PublishBlock returns once all replicas acknowledged the message
+func NewPublisher(topic string,
+	address []string,
+	strategy models.KeyStrategy,
+	mode models.Mode,
+	producer config.Producer,
+	log *zap.Logger) *Publisher {
 	w := kafka.NewWriter(kafka.WriterConfig{
-		Brokers:  address,
-		Topic:    topic,
-		Balancer: &kafka.LeastBytes{},
-		Async:    true,
+		Brokers:      address,
+		Topic:        topic,
+		Balancer:     balancer(),
+		BatchTimeout: batchTimeout,
+		BatchBytes:   producer.MaxMessageBytes + chunkHeadroom,
+		RequiredAcks: int(kafka.RequireAll),
 	})
+	w.Compression = compression(producer.Compression)
 
 	return &Publisher{
-		log:     log,
-		w:       w,
-		address: address,
+		log:             log,
+		w:               w,
+		strategy:        strategy,
+		mode:            mode,
+		maxMessageBytes: producer.MaxMessageBytes,
 	}
 }
 
-func (p *Publisher) PublishBlock(ctx context.Context, block []byte) {
-	if err := p.w.WriteMessages(ctx, kafka.Message{Value: block}); err != nil {
-		p.log.Fatal(fmt.Sprintf("failed to write messages: %s", err.Error()))
+func compression(codec models.Compression) kafka.Compression {
+	switch codec {
+	case models.CompressionSnappy:
+		return kafka.Snappy
+	case models.CompressionZstd:
+		return kafka.Zstd
+	default:
+		return 0
 	}
 }
 
-func (p *Publisher) Close() {
-	if err := p.w.Close(); err != nil {
-		p.log.Fatal(fmt.Sprintf("failed to close writer: %s", err.Error()))
+// messages - wrap payload with key and headers, payload above max message size is split into chunks
+func (p *Publisher) messages(meta Meta, payload []byte) []kafka.Message {
+	parts := split(meta, p.mode, messageKey(p.strategy, meta), payload, p.maxMessageBytes)
+	msgs := make([]kafka.Message, 0, len(parts))
+	for _, part := range parts {
+		msgs = append(msgs, kafka.Message{
+			Key:     part.key,
+			Value:   part.value,
+			Headers: part.headers,
+		})
 	}
+	return msgs
 }
 
-// PublishFailedBlock Create a temporary failed publisher and return block to sender
-func (p *Publisher) PublishFailedBlock(ctx context.Context, block models.Block) bool {
-	failedBlocksWriter := kafka.NewWriter(kafka.WriterConfig{
-		Brokers:  p.address,
-		Topic:    "failed_blocks",
-		Balancer: &kafka.LeastBytes{},
-		Async:    true,
-	})
-	Value, err := json.Marshal(block)
-	if err != nil {
-		p.log.Error(err.Error())
-		return false
+// write - write messages with exponential backoff, gives up after maxAttempts or when ctx is done
+func (p *Publisher) write(ctx context.Context, msgs ...kafka.Message) (err error) {
+	log, w := p.log, p.w
+	ctx, span := tracing.Start(ctx, "kafka.Publish",
+		tracing.Topic.String(w.Topic),
+		tracing.Messages.Int(len(msgs)))
+	defer func(start time.Time) {
+		metrics.ObservePublish(w.Topic, start, err)
+		tracing.End(span, err)
+	}(time.Now())
+	backoff := initialBackoff
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if err = w.WriteMessages(ctx, msgs...); err == nil {
+			return nil
+		}
+		if attempt == maxAttempts {
+			break
+		}
+		log.Warn("failed to write messages, retrying",
+			zap.String("topic", w.Topic),
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", backoff),
+			zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
 	}
-	if err := failedBlocksWriter.WriteMessages(ctx, kafka.Message{Value: Value}); err != nil {
-		p.log.Fatal(fmt.Sprintf("failed to write messages: %s", err.Error()))
-		return false
+	return fmt.Errorf("failed to write messages to %s after %d attempts: %w", w.Topic, maxAttempts, err)
+}
+
+// PublishBlock - write payloads of block in one batch
+func (p *Publisher) PublishBlock(ctx context.Context, meta Meta, blocks ...[]byte) error {
+	msgs := make([]kafka.Message, 0, len(blocks))
+	for _, block := range blocks {
+		msgs = append(msgs, p.messages(meta, block)...)
+	}
+	return p.write(ctx, msgs...)
+}
+
+func (p *Publisher) Close() error {
+	if err := p.w.Close(); err != nil {
+		return fmt.Errorf("failed to close writer: %w", err)
 	}
-	return true
+	return nil
 }
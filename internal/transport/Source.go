@@ -0,0 +1,21 @@
+package transport
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Source - input of blocks, processed message is either committed or rolled back to be fetched again
+type Source interface {
+	Fetch(ctx context.Context) (kafka.Message, error)
+	Commit(ctx context.Context, msg kafka.Message) error
+	Rollback(ctx context.Context, msg kafka.Message) error
+	Close()
+}
+
+// BlockPublisher - writes payloads of block into one topic
+type BlockPublisher interface {
+	PublishBlock(ctx context.Context, meta Meta, blocks ...[]byte) error
+	Close() error
+}
@@ -0,0 +1,206 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kattana-io/tron-blocks-parser/internal/config"
+	"github.com/kattana-io/tron-blocks-parser/internal/metrics"
+	"github.com/kattana-io/tron-blocks-parser/internal/models"
+	"github.com/kattana-io/tron-blocks-parser/internal/tracing"
+	"github.com/segmentio/kafka-go"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.uber.org/zap"
+)
+
+// errTxAborted - transaction was aborted by the broker (e.g. rebalance), block will be fetched again
+var errTxAborted = errors.New("transaction aborted, block will be consumed again")
+
+/**
+ * TxSession - consumes blocks and publishes outputs in Kafka transactions,
+ * outputs and consumer offset of a block are committed atomically.
+ * Producer is idempotent, consumer reads only committed messages.
+ * Transaction begins with the first output of block, so parsing and RETRY backoff don't count towards its timeout.
+ * Aborted transaction rewinds consumer to the last committed offset.
+ * End commits offsets of everything polled, so records are polled one by one:
+ * otherwise commit of the first record would commit the rest of the batch before it is processed
+ */
+type TxSession struct {
+	log             *zap.Logger
+	s               groupTransactSession
+	inTx            bool
+	strategy        models.KeyStrategy
+	mode            models.Mode
+	maxMessageBytes int
+}
+
+// groupTransactSession - methods of kgo.GroupTransactSession used by TxSession
+type groupTransactSession interface {
+	PollRecords(ctx context.Context, maxPollRecords int) kgo.Fetches
+	ProduceSync(ctx context.Context, rs ...*kgo.Record) kgo.ProduceResults
+	Begin() error
+	End(ctx context.Context, commit kgo.TransactionEndTry) (bool, error)
+	Close()
+}
+
+func NewTxSession(topic, groupID string,
+	address []string,
+	tx config.Transactions,
+	reader config.Reader,
+	producer config.Producer,
+	strategy models.KeyStrategy,
+	mode models.Mode,
+	log *zap.Logger) (*TxSession, error) {
+	s, err := kgo.NewGroupTransactSession(
+		kgo.SeedBrokers(address...),
+		kgo.TransactionalID(tx.ID),
+		kgo.TransactionTimeout(tx.Timeout),
+		kgo.ConsumerGroup(groupID),
+		kgo.ConsumeTopics(topic),
+		kgo.FetchIsolationLevel(kgo.ReadCommitted()),
+		kgo.RequireStableFetchOffsets(),
+		kgo.FetchMinBytes(int32(reader.MinBytes)),
+		kgo.FetchMaxBytes(int32(reader.MaxBytes)),
+		kgo.FetchMaxWait(reader.MaxWait),
+		kgo.ProducerBatchMaxBytes(int32(producer.MaxMessageBytes+chunkHeadroom)),
+		kgo.ProducerBatchCompression(kgoCompression(producer.Compression)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create transactional session: %w", err)
+	}
+	return &TxSession{
+		log:             log,
+		s:               s,
+		strategy:        strategy,
+		mode:            mode,
+		maxMessageBytes: producer.MaxMessageBytes,
+	}, nil
+}
+
+func kgoCompression(codec models.Compression) kgo.CompressionCodec {
+	switch codec {
+	case models.CompressionSnappy:
+		return kgo.SnappyCompression()
+	case models.CompressionZstd:
+		return kgo.ZstdCompression()
+	default:
+		return kgo.NoCompression()
+	}
+}
+
+// Fetch - read next message, transaction for its outputs begins with the first of them
+func (t *TxSession) Fetch(ctx context.Context) (kafka.Message, error) {
+	var records []*kgo.Record
+	for len(records) == 0 {
+		fetches := t.s.PollRecords(ctx, 1)
+		if err := ctx.Err(); err != nil {
+			return kafka.Message{}, err
+		}
+		if err := fetches.Err(); err != nil {
+			return kafka.Message{}, err
+		}
+		records = fetches.Records()
+	}
+	r := records[0]
+	return kafka.Message{
+		Topic:     r.Topic,
+		Partition: int(r.Partition),
+		Offset:    r.Offset,
+		Key:       r.Key,
+		Value:     r.Value,
+		Time:      r.Timestamp,
+	}, nil
+}
+
+// begin - start transaction unless outputs of current message already started it
+func (t *TxSession) begin() error {
+	if t.inTx {
+		return nil
+	}
+	if err := t.s.Begin(); err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	t.inTx = true
+	return nil
+}
+
+// end - finish transaction, message without outputs commits or aborts just its offset
+func (t *TxSession) end(ctx context.Context, commit kgo.TransactionEndTry) (bool, error) {
+	if err := t.begin(); err != nil {
+		return false, err
+	}
+	t.inTx = false
+	return t.s.End(ctx, commit)
+}
+
+// Commit - commit outputs together with offset of message
+func (t *TxSession) Commit(ctx context.Context, _ kafka.Message) error {
+	committed, err := t.end(ctx, kgo.TryCommit)
+	if err == nil && !committed {
+		// Session is rewound to the last committed offset
+		err = errTxAborted
+	}
+	return err
+}
+
+// Rollback - abort outputs of message, it is fetched again after the last committed offset
+func (t *TxSession) Rollback(ctx context.Context, _ kafka.Message) error {
+	if _, err := t.end(ctx, kgo.TryAbort); err != nil {
+		return fmt.Errorf("abort transaction: %w", err)
+	}
+	return nil
+}
+
+func (t *TxSession) Close() {
+	t.s.Close()
+}
+
+// Publisher - writer of topic within current transaction
+func (t *TxSession) Publisher(topic string) *TxPublisher {
+	return &TxPublisher{t: t, topic: topic}
+}
+
+// TxPublisher - writes into topic within transaction of TxSession
+type TxPublisher struct {
+	t     *TxSession
+	topic string
+}
+
+func (p *TxPublisher) PublishBlock(ctx context.Context, meta Meta, blocks ...[]byte) error {
+	if err := p.t.begin(); err != nil {
+		return err
+	}
+	key := messageKey(p.t.strategy, meta)
+	records := make([]*kgo.Record, 0, len(blocks))
+	for _, block := range blocks {
+		for _, part := range split(meta, p.t.mode, key, block, p.t.maxMessageBytes) {
+			r := &kgo.Record{
+				Topic: p.topic,
+				Key:   part.key,
+				Value: part.value,
+			}
+			for _, h := range part.headers {
+				r.Headers = append(r.Headers, kgo.RecordHeader{Key: h.Key, Value: h.Value})
+			}
+			records = append(records, r)
+		}
+	}
+	start := time.Now()
+	ctx, span := tracing.Start(ctx, "kafka.Publish",
+		tracing.Topic.String(p.topic),
+		tracing.Messages.Int(len(records)))
+	err := p.t.s.ProduceSync(ctx, records...).FirstErr()
+	metrics.ObservePublish(p.topic, start, err)
+	tracing.End(span, err)
+	if err != nil {
+		return fmt.Errorf("failed to write messages to %s: %w", p.topic, err)
+	}
+	return nil
+}
+
+// Close - client is owned by TxSession
+func (p *TxPublisher) Close() error {
+	return nil
+}
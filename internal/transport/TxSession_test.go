@@ -0,0 +1,103 @@
+package transport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// fakeTransactSession - like kgo.GroupTransactSession, End commits offsets of everything polled
+// and abort rewinds polling to the last committed offset
+type fakeTransactSession struct {
+	records   []*kgo.Record
+	polled    int
+	committed int
+}
+
+func (f *fakeTransactSession) PollRecords(_ context.Context, maxPollRecords int) kgo.Fetches {
+	end := len(f.records)
+	if maxPollRecords > 0 && f.polled+maxPollRecords < end {
+		end = f.polled + maxPollRecords
+	}
+	records := f.records[f.polled:end]
+	f.polled = end
+	return kgo.Fetches{{Topics: []kgo.FetchTopic{{Topic: "blocks", Partitions: []kgo.FetchPartition{{Records: records}}}}}}
+}
+
+func (f *fakeTransactSession) ProduceSync(context.Context, ...*kgo.Record) kgo.ProduceResults {
+	return nil
+}
+
+func (f *fakeTransactSession) Begin() error {
+	return nil
+}
+
+func (f *fakeTransactSession) End(_ context.Context, commit kgo.TransactionEndTry) (bool, error) {
+	if commit == kgo.TryCommit {
+		f.committed = f.polled
+		return true, nil
+	}
+	f.polled = f.committed
+	return false, nil
+}
+
+func (f *fakeTransactSession) Close() {}
+
+func TestTxSession_Redelivery(t *testing.T) {
+	type step struct {
+		wantOffset int64
+		commit     bool
+		// wantCommitted - committed offset after the step, a crash resumes from it
+		wantCommitted int
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{name: "Commit covers only current record", steps: []step{
+			{wantOffset: 0, commit: true, wantCommitted: 1},
+			{wantOffset: 1, commit: true, wantCommitted: 2},
+		}},
+		{name: "Failed later record of one poll is redelivered", steps: []step{
+			{wantOffset: 0, commit: true, wantCommitted: 1},
+			{wantOffset: 1, commit: false, wantCommitted: 1},
+			{wantOffset: 1, commit: true, wantCommitted: 2},
+			{wantOffset: 2, commit: true, wantCommitted: 3},
+		}},
+		{name: "Failed first record is redelivered", steps: []step{
+			{wantOffset: 0, commit: false, wantCommitted: 0},
+			{wantOffset: 0, commit: true, wantCommitted: 1},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := &fakeTransactSession{}
+			for offset := int64(0); offset < 3; offset++ {
+				fake.records = append(fake.records, &kgo.Record{Topic: "blocks", Offset: offset})
+			}
+			session := &TxSession{s: fake}
+			for i, s := range tt.steps {
+				msg, err := session.Fetch(ctx)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if msg.Offset != s.wantOffset {
+					t.Fatalf("step %d: fetched offset %v, want %v", i, msg.Offset, s.wantOffset)
+				}
+				if s.commit {
+					err = session.Commit(ctx, msg)
+				} else {
+					err = session.Rollback(ctx, msg)
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				if fake.committed != s.wantCommitted {
+					t.Errorf("step %d: committed offset %v, want %v", i, fake.committed, s.wantCommitted)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Package chunks splits payloads which exceed Kafka message size limit and reassembles them on the consumer side.
+
+Every chunk of payload is published with the same key and headers chunk_id, chunk_index and chunk_total,
+so chunks of one payload land on the same partition in order. Messages without chunk headers are not chunked.
+*/
+package chunks
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Headers of chunked message
+const (
+	HeaderID    = "chunk_id"
+	HeaderIndex = "chunk_index"
+	HeaderTotal = "chunk_total"
+)
+
+// checksumLen - length of payload checksum in chunk id, hex encoded
+const checksumLen = 16
+
+// Chunk - part of payload
+type Chunk struct {
+	// ID - <network>:<block number>:<checksum of payload>, equal for redelivered payload
+	ID    string
+	Index int
+	Total int
+	Data  []byte
+}
+
+// ID - chunk id of payload of block
+func ID(network string, number uint64, payload []byte) string {
+	return fmt.Sprintf("%s:%d:%s", network, number, checksum(payload))
+}
+
+func checksum(payload []byte) string {
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])[:checksumLen]
+}
+
+// Split - split payload into chunks not larger than size
+func Split(id string, payload []byte, size int) []Chunk {
+	total := (len(payload) + size - 1) / size
+	result := make([]Chunk, 0, total)
+	for i := 0; i < total; i++ {
+		end := (i + 1) * size
+		if end > len(payload) {
+			end = len(payload)
+		}
+		result = append(result, Chunk{ID: id, Index: i, Total: total, Data: payload[i*size : end]})
+	}
+	return result
+}
+
+// Header - message header, same layout as in Kafka clients
+type Header struct {
+	Key   string
+	Value []byte
+}
+
+// Headers - headers of chunk to attach to message
+func (c Chunk) Headers() []Header {
+	return []Header{
+		{Key: HeaderID, Value: []byte(c.ID)},
+		{Key: HeaderIndex, Value: []byte(strconv.Itoa(c.Index))},
+		{Key: HeaderTotal, Value: []byte(strconv.Itoa(c.Total))},
+	}
+}
+
+// Parse - read chunk from message headers, ok is false for messages which are not chunked
+func Parse(headers map[string][]byte, value []byte) (chunk Chunk, ok bool, err error) {
+	id, ok := headers[HeaderID]
+	if !ok {
+		return Chunk{}, false, nil
+	}
+	index, err := strconv.Atoi(string(headers[HeaderIndex]))
+	if err != nil {
+		return Chunk{}, true, fmt.Errorf("invalid %s: %w", HeaderIndex, err)
+	}
+	total, err := strconv.Atoi(string(headers[HeaderTotal]))
+	if err != nil {
+		return Chunk{}, true, fmt.Errorf("invalid %s: %w", HeaderTotal, err)
+	}
+	if index < 0 || total <= 0 || index >= total {
+		return Chunk{}, true, fmt.Errorf("invalid chunk %d of %d", index, total)
+	}
+	return Chunk{ID: string(id), Index: index, Total: total, Data: value}, true, nil
+}
+
+type partial struct {
+	parts    [][]byte
+	received int
+}
+
+/**
+ * Assembler - collects chunks until payload is complete,
+ * keeps at most maxPending incomplete payloads, the oldest one is dropped first
+ */
+type Assembler struct {
+	maxPending int
+	pending    map[string]*partial
+	order      []string
+}
+
+func NewAssembler(maxPending int) *Assembler {
+	return &Assembler{
+		maxPending: maxPending,
+		pending:    make(map[string]*partial),
+	}
+}
+
+var ErrChecksum = errors.New("chunks: checksum mismatch")
+
+// Add - add chunk, returns payload once all chunks of it are received, duplicated chunks are ignored
+func (a *Assembler) Add(c Chunk) (payload []byte, complete bool, err error) {
+	p, ok := a.pending[c.ID]
+	if !ok {
+		p = &partial{parts: make([][]byte, c.Total)}
+		a.pending[c.ID] = p
+		a.order = append(a.order, c.ID)
+		a.evict()
+	}
+	if c.Total != len(p.parts) || c.Index >= len(p.parts) {
+		return nil, false, fmt.Errorf("chunks: chunk %d of %d doesn't match payload of %d chunks", c.Index, c.Total, len(p.parts))
+	}
+	if p.parts[c.Index] == nil {
+		p.parts[c.Index] = c.Data
+		p.received++
+	}
+	if p.received < len(p.parts) {
+		return nil, false, nil
+	}
+
+	a.remove(c.ID)
+	for _, part := range p.parts {
+		payload = append(payload, part...)
+	}
+	if i := strings.LastIndex(c.ID, ":"); i >= 0 && c.ID[i+1:] != checksum(payload) {
+		return nil, false, ErrChecksum
+	}
+	return payload, true, nil
+}
+
+// Pending - number of incomplete payloads
+func (a *Assembler) Pending() int {
+	return len(a.pending)
+}
+
+func (a *Assembler) evict() {
+	for a.maxPending > 0 && len(a.order) > a.maxPending {
+		delete(a.pending, a.order[0])
+		a.order = a.order[1:]
+	}
+}
+
+func (a *Assembler) remove(id string) {
+	delete(a.pending, id)
+	for i, pending := range a.order {
+		if pending == id {
+			a.order = append(a.order[:i], a.order[i+1:]...)
+			break
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package chunks
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestSplitAndAssemble(t *testing.T) {
+	payload := bytes.Repeat([]byte("holders"), 1000)
+	id := ID("TRON", 42, payload)
+	tests := []struct {
+		name  string
+		size  int
+		order []int
+		total int
+	}{
+		{name: "In order", size: 1000, order: []int{0, 1, 2, 3, 4, 5, 6}, total: 7},
+		{name: "Out of order with duplicate", size: 3000, order: []int{2, 0, 0, 1}, total: 3},
+		{name: "Single chunk", size: 7000, order: []int{0}, total: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts := Split(id, payload, tt.size)
+			if len(parts) != tt.total {
+				t.Fatalf("Split() chunks = %d, want %d", len(parts), tt.total)
+			}
+			a := NewAssembler(10)
+			var got []byte
+			var complete bool
+			for _, i := range tt.order {
+				var err error
+				if got, complete, err = a.Add(parts[i]); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if !complete || !bytes.Equal(got, payload) {
+				t.Errorf("Add() complete = %v, payload equal = %v", complete, bytes.Equal(got, payload))
+			}
+			if a.Pending() != 0 {
+				t.Errorf("Pending() = %d, want 0", a.Pending())
+			}
+		})
+	}
+}
+
+func TestAssembler_Checksum(t *testing.T) {
+	parts := Split(ID("TRON", 42, []byte("original")), []byte("modified"), 4)
+	a := NewAssembler(10)
+	_, _, _ = a.Add(parts[0])
+	if _, _, err := a.Add(parts[1]); !errors.Is(err, ErrChecksum) {
+		t.Errorf("Add() error = %v, want %v", err, ErrChecksum)
+	}
+}
+
+func TestAssembler_Evict(t *testing.T) {
+	a := NewAssembler(1)
+	_, _, _ = a.Add(Split("a", []byte("abcd"), 2)[0])
+	_, _, _ = a.Add(Split("b", []byte("abcd"), 2)[0])
+	if a.Pending() != 1 {
+		t.Errorf("Pending() = %d, want 1", a.Pending())
+	}
+}
+
+func TestParse(t *testing.T) {
+	chunk := Chunk{ID: "TRON:42:0011", Index: 1, Total: 2, Data: []byte("data")}
+	headers := map[string][]byte{}
+	for _, h := range chunk.Headers() {
+		headers[h.Key] = h.Value
+	}
+	got, ok, err := Parse(headers, chunk.Data)
+	if err != nil || !ok || got.ID != chunk.ID || got.Index != 1 || got.Total != 2 {
+		t.Errorf("Parse() = %v, %v, %v", got, ok, err)
+	}
+	if _, ok, _ = Parse(map[string][]byte{}, nil); ok {
+		t.Errorf("Parse() ok = true for message without chunk headers")
+	}
+	headers[HeaderIndex] = []byte("2")
+	if _, _, err = Parse(headers, nil); err == nil {
+		t.Errorf("Parse() expected error for index out of range")
+	}
+}
@@ -0,0 +1,2064 @@
+// Payloads published with protobuf encoding (KAFKA_ENCODING=protobuf).
+// Amounts, prices and USD values are decimal strings, so they keep full precision of uint256 and decimals.
+// Regenerate with `go generate ./pkg/pb` after changes, bump parser.SchemaVersion on breaking ones.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: parser.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Source - where USD price came from
+type Source int32
+
+const (
+	Source_SOURCE_NONE       Source = 0
+	Source_SOURCE_STABLE     Source = 1
+	Source_SOURCE_BLOCK      Source = 2
+	Source_SOURCE_PREVIOUS   Source = 3
+	Source_SOURCE_CHECKPOINT Source = 4
+	Source_SOURCE_LIVE       Source = 5
+)
+
+// Enum value maps for Source.
+var (
+	Source_name = map[int32]string{
+		0: "SOURCE_NONE",
+		1: "SOURCE_STABLE",
+		2: "SOURCE_BLOCK",
+		3: "SOURCE_PREVIOUS",
+		4: "SOURCE_CHECKPOINT",
+		5: "SOURCE_LIVE",
+	}
+	Source_value = map[string]int32{
+		"SOURCE_NONE":       0,
+		"SOURCE_STABLE":     1,
+		"SOURCE_BLOCK":      2,
+		"SOURCE_PREVIOUS":   3,
+		"SOURCE_CHECKPOINT": 4,
+		"SOURCE_LIVE":       5,
+	}
+)
+
+func (x Source) Enum() *Source {
+	p := new(Source)
+	*p = x
+	return p
+}
+
+func (x Source) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Source) Descriptor() protoreflect.EnumDescriptor {
+	return file_parser_proto_enumTypes[0].Descriptor()
+}
+
+func (Source) Type() protoreflect.EnumType {
+	return &file_parser_proto_enumTypes[0]
+}
+
+func (x Source) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Source.Descriptor instead.
+func (Source) EnumDescriptor() ([]byte, []int) {
+	return file_parser_proto_rawDescGZIP(), []int{0}
+}
+
+// Block - block which payload belongs to, timestamp is in seconds
+type Block struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Network   string `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	Number    uint64 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	Timestamp uint64 `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Node      string `protobuf:"bytes,4,opt,name=node,proto3" json:"node,omitempty"`
+	Notify    bool   `protobuf:"varint,5,opt,name=notify,proto3" json:"notify,omitempty"`
+}
+
+func (x *Block) Reset() {
+	*x = Block{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_parser_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Block) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Block) ProtoMessage() {}
+
+func (x *Block) ProtoReflect() protoreflect.Message {
+	mi := &file_parser_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Block.ProtoReflect.Descriptor instead.
+func (*Block) Descriptor() ([]byte, []int) {
+	return file_parser_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Block) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *Block) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Block) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *Block) GetNode() string {
+	if x != nil {
+		return x.Node
+	}
+	return ""
+}
+
+func (x *Block) GetNotify() bool {
+	if x != nil {
+		return x.Notify
+	}
+	return false
+}
+
+// Provenance - source of the rate and the pair which defined it,
+// distance - blocks between the block and the checkpoint rate was restored from, zero for other sources
+type Provenance struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Source   Source `protobuf:"varint,1,opt,name=source,proto3,enum=tron_blocks_parser.v1.Source" json:"source,omitempty"`
+	Pair     string `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
+	Distance uint64 `protobuf:"varint,3,opt,name=distance,proto3" json:"distance,omitempty"`
+}
+
+func (x *Provenance) Reset() {
+	*x = Provenance{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_parser_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Provenance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Provenance) ProtoMessage() {}
+
+func (x *Provenance) ProtoReflect() protoreflect.Message {
+	mi := &file_parser_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Provenance.ProtoReflect.Descriptor instead.
+func (*Provenance) Descriptor() ([]byte, []int) {
+	return file_parser_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Provenance) GetSource() Source {
+	if x != nil {
+		return x.Source
+	}
+	return Source_SOURCE_NONE
+}
+
+func (x *Provenance) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *Provenance) GetDistance() uint64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+type PairSwap struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tx               string                 `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	Date             *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	Chain            string                 `protobuf:"bytes,3,opt,name=chain,proto3" json:"chain,omitempty"`
+	BlockNumber      uint64                 `protobuf:"varint,4,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	Pair             string                 `protobuf:"bytes,5,opt,name=pair,proto3" json:"pair,omitempty"`
+	Amount0          string                 `protobuf:"bytes,6,opt,name=amount0,proto3" json:"amount0,omitempty"`
+	Amount1          string                 `protobuf:"bytes,7,opt,name=amount1,proto3" json:"amount1,omitempty"`
+	Buy              bool                   `protobuf:"varint,8,opt,name=buy,proto3" json:"buy,omitempty"`
+	PriceA           string                 `protobuf:"bytes,9,opt,name=price_a,json=priceA,proto3" json:"price_a,omitempty"`
+	PriceAUsd        string                 `protobuf:"bytes,10,opt,name=price_a_usd,json=priceAUsd,proto3" json:"price_a_usd,omitempty"`
+	PriceB           string                 `protobuf:"bytes,11,opt,name=price_b,json=priceB,proto3" json:"price_b,omitempty"`
+	PriceBUsd        string                 `protobuf:"bytes,12,opt,name=price_b_usd,json=priceBUsd,proto3" json:"price_b_usd,omitempty"`
+	Bot              bool                   `protobuf:"varint,13,opt,name=bot,proto3" json:"bot,omitempty"`
+	Wallet           string                 `protobuf:"bytes,14,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	Order            int64                  `protobuf:"varint,15,opt,name=order,proto3" json:"order,omitempty"`
+	ValueUsd         string                 `protobuf:"bytes,16,opt,name=value_usd,json=valueUsd,proto3" json:"value_usd,omitempty"`
+	PriceAProvenance *Provenance            `protobuf:"bytes,17,opt,name=price_a_provenance,json=priceAProvenance,proto3" json:"price_a_provenance,omitempty"`
+	PriceBProvenance *Provenance            `protobuf:"bytes,18,opt,name=price_b_provenance,json=priceBProvenance,proto3" json:"price_b_provenance,omitempty"`
+	ValueProvenance  *Provenance            `protobuf:"bytes,19,opt,name=value_provenance,json=valueProvenance,proto3" json:"value_provenance,omitempty"`
+}
+
+func (x *PairSwap) Reset() {
+	*x = PairSwap{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_parser_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PairSwap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PairSwap) ProtoMessage() {}
+
+func (x *PairSwap) ProtoReflect() protoreflect.Message {
+	mi := &file_parser_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PairSwap.ProtoReflect.Descriptor instead.
+func (*PairSwap) Descriptor() ([]byte, []int) {
+	return file_parser_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PairSwap) GetTx() string {
+	if x != nil {
+		return x.Tx
+	}
+	return ""
+}
+
+func (x *PairSwap) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *PairSwap) GetChain() string {
+	if x != nil {
+		return x.Chain
+	}
+	return ""
+}
+
+func (x *PairSwap) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *PairSwap) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *PairSwap) GetAmount0() string {
+	if x != nil {
+		return x.Amount0
+	}
+	return ""
+}
+
+func (x *PairSwap) GetAmount1() string {
+	if x != nil {
+		return x.Amount1
+	}
+	return ""
+}
+
+func (x *PairSwap) GetBuy() bool {
+	if x != nil {
+		return x.Buy
+	}
+	return false
+}
+
+func (x *PairSwap) GetPriceA() string {
+	if x != nil {
+		return x.PriceA
+	}
+	return ""
+}
+
+func (x *PairSwap) GetPriceAUsd() string {
+	if x != nil {
+		return x.PriceAUsd
+	}
+	return ""
+}
+
+func (x *PairSwap) GetPriceB() string {
+	if x != nil {
+		return x.PriceB
+	}
+	return ""
+}
+
+func (x *PairSwap) GetPriceBUsd() string {
+	if x != nil {
+		return x.PriceBUsd
+	}
+	return ""
+}
+
+func (x *PairSwap) GetBot() bool {
+	if x != nil {
+		return x.Bot
+	}
+	return false
+}
+
+func (x *PairSwap) GetWallet() string {
+	if x != nil {
+		return x.Wallet
+	}
+	return ""
+}
+
+func (x *PairSwap) GetOrder() int64 {
+	if x != nil {
+		return x.Order
+	}
+	return 0
+}
+
+func (x *PairSwap) GetValueUsd() string {
+	if x != nil {
+		return x.ValueUsd
+	}
+	return ""
+}
+
+func (x *PairSwap) GetPriceAProvenance() *Provenance {
+	if x != nil {
+		return x.PriceAProvenance
+	}
+	return nil
+}
+
+func (x *PairSwap) GetPriceBProvenance() *Provenance {
+	if x != nil {
+		return x.PriceBProvenance
+	}
+	return nil
+}
+
+func (x *PairSwap) GetValueProvenance() *Provenance {
+	if x != nil {
+		return x.ValueProvenance
+	}
+	return nil
+}
+
+type DirectSwap struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tx               string                 `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	Date             *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	Chain            string                 `protobuf:"bytes,3,opt,name=chain,proto3" json:"chain,omitempty"`
+	BlockNumber      uint64                 `protobuf:"varint,4,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	Protocol         string                 `protobuf:"bytes,5,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	SrcToken         string                 `protobuf:"bytes,6,opt,name=src_token,json=srcToken,proto3" json:"src_token,omitempty"`
+	DstToken         string                 `protobuf:"bytes,7,opt,name=dst_token,json=dstToken,proto3" json:"dst_token,omitempty"`
+	Amount0          string                 `protobuf:"bytes,8,opt,name=amount0,proto3" json:"amount0,omitempty"`
+	Amount1          string                 `protobuf:"bytes,9,opt,name=amount1,proto3" json:"amount1,omitempty"`
+	PriceA           string                 `protobuf:"bytes,10,opt,name=price_a,json=priceA,proto3" json:"price_a,omitempty"`
+	PriceAUsd        string                 `protobuf:"bytes,11,opt,name=price_a_usd,json=priceAUsd,proto3" json:"price_a_usd,omitempty"`
+	PriceB           string                 `protobuf:"bytes,12,opt,name=price_b,json=priceB,proto3" json:"price_b,omitempty"`
+	PriceBUsd        string                 `protobuf:"bytes,13,opt,name=price_b_usd,json=priceBUsd,proto3" json:"price_b_usd,omitempty"`
+	Wallet           string                 `protobuf:"bytes,14,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	Order            int64                  `protobuf:"varint,15,opt,name=order,proto3" json:"order,omitempty"`
+	ValueUsd         string                 `protobuf:"bytes,16,opt,name=value_usd,json=valueUsd,proto3" json:"value_usd,omitempty"`
+	PriceAProvenance *Provenance            `protobuf:"bytes,17,opt,name=price_a_provenance,json=priceAProvenance,proto3" json:"price_a_provenance,omitempty"`
+	PriceBProvenance *Provenance            `protobuf:"bytes,18,opt,name=price_b_provenance,json=priceBProvenance,proto3" json:"price_b_provenance,omitempty"`
+	ValueProvenance  *Provenance            `protobuf:"bytes,19,opt,name=value_provenance,json=valueProvenance,proto3" json:"value_provenance,omitempty"`
+}
+
+func (x *DirectSwap) Reset() {
+	*x = DirectSwap{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_parser_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DirectSwap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DirectSwap) ProtoMessage() {}
+
+func (x *DirectSwap) ProtoReflect() protoreflect.Message {
+	mi := &file_parser_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DirectSwap.ProtoReflect.Descriptor instead.
+func (*DirectSwap) Descriptor() ([]byte, []int) {
+	return file_parser_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DirectSwap) GetTx() string {
+	if x != nil {
+		return x.Tx
+	}
+	return ""
+}
+
+func (x *DirectSwap) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *DirectSwap) GetChain() string {
+	if x != nil {
+		return x.Chain
+	}
+	return ""
+}
+
+func (x *DirectSwap) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *DirectSwap) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *DirectSwap) GetSrcToken() string {
+	if x != nil {
+		return x.SrcToken
+	}
+	return ""
+}
+
+func (x *DirectSwap) GetDstToken() string {
+	if x != nil {
+		return x.DstToken
+	}
+	return ""
+}
+
+func (x *DirectSwap) GetAmount0() string {
+	if x != nil {
+		return x.Amount0
+	}
+	return ""
+}
+
+func (x *DirectSwap) GetAmount1() string {
+	if x != nil {
+		return x.Amount1
+	}
+	return ""
+}
+
+func (x *DirectSwap) GetPriceA() string {
+	if x != nil {
+		return x.PriceA
+	}
+	return ""
+}
+
+func (x *DirectSwap) GetPriceAUsd() string {
+	if x != nil {
+		return x.PriceAUsd
+	}
+	return ""
+}
+
+func (x *DirectSwap) GetPriceB() string {
+	if x != nil {
+		return x.PriceB
+	}
+	return ""
+}
+
+func (x *DirectSwap) GetPriceBUsd() string {
+	if x != nil {
+		return x.PriceBUsd
+	}
+	return ""
+}
+
+func (x *DirectSwap) GetWallet() string {
+	if x != nil {
+		return x.Wallet
+	}
+	return ""
+}
+
+func (x *DirectSwap) GetOrder() int64 {
+	if x != nil {
+		return x.Order
+	}
+	return 0
+}
+
+func (x *DirectSwap) GetValueUsd() string {
+	if x != nil {
+		return x.ValueUsd
+	}
+	return ""
+}
+
+func (x *DirectSwap) GetPriceAProvenance() *Provenance {
+	if x != nil {
+		return x.PriceAProvenance
+	}
+	return nil
+}
+
+func (x *DirectSwap) GetPriceBProvenance() *Provenance {
+	if x != nil {
+		return x.PriceBProvenance
+	}
+	return nil
+}
+
+func (x *DirectSwap) GetValueProvenance() *Provenance {
+	if x != nil {
+		return x.ValueProvenance
+	}
+	return nil
+}
+
+type LiquidityEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockNumber      uint64                 `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	Date             *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	Tx               string                 `protobuf:"bytes,3,opt,name=tx,proto3" json:"tx,omitempty"`
+	Pair             string                 `protobuf:"bytes,4,opt,name=pair,proto3" json:"pair,omitempty"`
+	Chain            string                 `protobuf:"bytes,5,opt,name=chain,proto3" json:"chain,omitempty"`
+	Klass            string                 `protobuf:"bytes,6,opt,name=klass,proto3" json:"klass,omitempty"`
+	Wallet           string                 `protobuf:"bytes,7,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	Order            int64                  `protobuf:"varint,8,opt,name=order,proto3" json:"order,omitempty"`
+	Reserve0         string                 `protobuf:"bytes,9,opt,name=reserve0,proto3" json:"reserve0,omitempty"`
+	Reserve1         string                 `protobuf:"bytes,10,opt,name=reserve1,proto3" json:"reserve1,omitempty"`
+	PriceA           string                 `protobuf:"bytes,11,opt,name=price_a,json=priceA,proto3" json:"price_a,omitempty"`
+	PriceAUsd        string                 `protobuf:"bytes,12,opt,name=price_a_usd,json=priceAUsd,proto3" json:"price_a_usd,omitempty"`
+	PriceB           string                 `protobuf:"bytes,13,opt,name=price_b,json=priceB,proto3" json:"price_b,omitempty"`
+	PriceBUsd        string                 `protobuf:"bytes,14,opt,name=price_b_usd,json=priceBUsd,proto3" json:"price_b_usd,omitempty"`
+	ReserveUsd       string                 `protobuf:"bytes,15,opt,name=reserve_usd,json=reserveUsd,proto3" json:"reserve_usd,omitempty"`
+	PriceAProvenance *Provenance            `protobuf:"bytes,16,opt,name=price_a_provenance,json=priceAProvenance,proto3" json:"price_a_provenance,omitempty"`
+	PriceBProvenance *Provenance            `protobuf:"bytes,17,opt,name=price_b_provenance,json=priceBProvenance,proto3" json:"price_b_provenance,omitempty"`
+	ValueProvenance  *Provenance            `protobuf:"bytes,18,opt,name=value_provenance,json=valueProvenance,proto3" json:"value_provenance,omitempty"`
+}
+
+func (x *LiquidityEvent) Reset() {
+	*x = LiquidityEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_parser_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LiquidityEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiquidityEvent) ProtoMessage() {}
+
+func (x *LiquidityEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_parser_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LiquidityEvent.ProtoReflect.Descriptor instead.
+func (*LiquidityEvent) Descriptor() ([]byte, []int) {
+	return file_parser_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LiquidityEvent) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *LiquidityEvent) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *LiquidityEvent) GetTx() string {
+	if x != nil {
+		return x.Tx
+	}
+	return ""
+}
+
+func (x *LiquidityEvent) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *LiquidityEvent) GetChain() string {
+	if x != nil {
+		return x.Chain
+	}
+	return ""
+}
+
+func (x *LiquidityEvent) GetKlass() string {
+	if x != nil {
+		return x.Klass
+	}
+	return ""
+}
+
+func (x *LiquidityEvent) GetWallet() string {
+	if x != nil {
+		return x.Wallet
+	}
+	return ""
+}
+
+func (x *LiquidityEvent) GetOrder() int64 {
+	if x != nil {
+		return x.Order
+	}
+	return 0
+}
+
+func (x *LiquidityEvent) GetReserve0() string {
+	if x != nil {
+		return x.Reserve0
+	}
+	return ""
+}
+
+func (x *LiquidityEvent) GetReserve1() string {
+	if x != nil {
+		return x.Reserve1
+	}
+	return ""
+}
+
+func (x *LiquidityEvent) GetPriceA() string {
+	if x != nil {
+		return x.PriceA
+	}
+	return ""
+}
+
+func (x *LiquidityEvent) GetPriceAUsd() string {
+	if x != nil {
+		return x.PriceAUsd
+	}
+	return ""
+}
+
+func (x *LiquidityEvent) GetPriceB() string {
+	if x != nil {
+		return x.PriceB
+	}
+	return ""
+}
+
+func (x *LiquidityEvent) GetPriceBUsd() string {
+	if x != nil {
+		return x.PriceBUsd
+	}
+	return ""
+}
+
+func (x *LiquidityEvent) GetReserveUsd() string {
+	if x != nil {
+		return x.ReserveUsd
+	}
+	return ""
+}
+
+func (x *LiquidityEvent) GetPriceAProvenance() *Provenance {
+	if x != nil {
+		return x.PriceAProvenance
+	}
+	return nil
+}
+
+func (x *LiquidityEvent) GetPriceBProvenance() *Provenance {
+	if x != nil {
+		return x.PriceBProvenance
+	}
+	return nil
+}
+
+func (x *LiquidityEvent) GetValueProvenance() *Provenance {
+	if x != nil {
+		return x.ValueProvenance
+	}
+	return nil
+}
+
+// TransferEvent - transfers are not emitted by TRON parser yet
+type TransferEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *TransferEvent) Reset() {
+	*x = TransferEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_parser_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransferEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferEvent) ProtoMessage() {}
+
+func (x *TransferEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_parser_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferEvent.ProtoReflect.Descriptor instead.
+func (*TransferEvent) Descriptor() ([]byte, []int) {
+	return file_parser_proto_rawDescGZIP(), []int{5}
+}
+
+type NewPair struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Factory     string `protobuf:"bytes,1,opt,name=factory,proto3" json:"factory,omitempty"`
+	Pair        string `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
+	Klass       string `protobuf:"bytes,3,opt,name=klass,proto3" json:"klass,omitempty"`
+	Network     string `protobuf:"bytes,4,opt,name=network,proto3" json:"network,omitempty"`
+	Node        string `protobuf:"bytes,5,opt,name=node,proto3" json:"node,omitempty"`
+	PoolCreated int64  `protobuf:"varint,6,opt,name=pool_created,json=poolCreated,proto3" json:"pool_created,omitempty"`
+}
+
+func (x *NewPair) Reset() {
+	*x = NewPair{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_parser_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewPair) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewPair) ProtoMessage() {}
+
+func (x *NewPair) ProtoReflect() protoreflect.Message {
+	mi := &file_parser_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewPair.ProtoReflect.Descriptor instead.
+func (*NewPair) Descriptor() ([]byte, []int) {
+	return file_parser_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *NewPair) GetFactory() string {
+	if x != nil {
+		return x.Factory
+	}
+	return ""
+}
+
+func (x *NewPair) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *NewPair) GetKlass() string {
+	if x != nil {
+		return x.Klass
+	}
+	return ""
+}
+
+func (x *NewPair) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *NewPair) GetNode() string {
+	if x != nil {
+		return x.Node
+	}
+	return ""
+}
+
+func (x *NewPair) GetPoolCreated() int64 {
+	if x != nil {
+		return x.PoolCreated
+	}
+	return 0
+}
+
+type Holder struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	From  string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To    string `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Tx    string `protobuf:"bytes,4,opt,name=tx,proto3" json:"tx,omitempty"`
+}
+
+func (x *Holder) Reset() {
+	*x = Holder{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_parser_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Holder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Holder) ProtoMessage() {}
+
+func (x *Holder) ProtoReflect() protoreflect.Message {
+	mi := &file_parser_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Holder.ProtoReflect.Descriptor instead.
+func (*Holder) Descriptor() ([]byte, []int) {
+	return file_parser_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Holder) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *Holder) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Holder) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *Holder) GetTx() string {
+	if x != nil {
+		return x.Tx
+	}
+	return ""
+}
+
+// TokenPrice - OHLC of token USD price within block
+type TokenPrice struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token     string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Open      string `protobuf:"bytes,2,opt,name=open,proto3" json:"open,omitempty"`
+	High      string `protobuf:"bytes,3,opt,name=high,proto3" json:"high,omitempty"`
+	Low       string `protobuf:"bytes,4,opt,name=low,proto3" json:"low,omitempty"`
+	Close     string `protobuf:"bytes,5,opt,name=close,proto3" json:"close,omitempty"`
+	Vwap      string `protobuf:"bytes,6,opt,name=vwap,proto3" json:"vwap,omitempty"`
+	Volume    string `protobuf:"bytes,7,opt,name=volume,proto3" json:"volume,omitempty"`
+	VolumeUsd string `protobuf:"bytes,8,opt,name=volume_usd,json=volumeUsd,proto3" json:"volume_usd,omitempty"`
+	Trades    int64  `protobuf:"varint,9,opt,name=trades,proto3" json:"trades,omitempty"`
+}
+
+func (x *TokenPrice) Reset() {
+	*x = TokenPrice{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_parser_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TokenPrice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenPrice) ProtoMessage() {}
+
+func (x *TokenPrice) ProtoReflect() protoreflect.Message {
+	mi := &file_parser_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenPrice.ProtoReflect.Descriptor instead.
+func (*TokenPrice) Descriptor() ([]byte, []int) {
+	return file_parser_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TokenPrice) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *TokenPrice) GetOpen() string {
+	if x != nil {
+		return x.Open
+	}
+	return ""
+}
+
+func (x *TokenPrice) GetHigh() string {
+	if x != nil {
+		return x.High
+	}
+	return ""
+}
+
+func (x *TokenPrice) GetLow() string {
+	if x != nil {
+		return x.Low
+	}
+	return ""
+}
+
+func (x *TokenPrice) GetClose() string {
+	if x != nil {
+		return x.Close
+	}
+	return ""
+}
+
+func (x *TokenPrice) GetVwap() string {
+	if x != nil {
+		return x.Vwap
+	}
+	return ""
+}
+
+func (x *TokenPrice) GetVolume() string {
+	if x != nil {
+		return x.Volume
+	}
+	return ""
+}
+
+func (x *TokenPrice) GetVolumeUsd() string {
+	if x != nil {
+		return x.VolumeUsd
+	}
+	return ""
+}
+
+func (x *TokenPrice) GetTrades() int64 {
+	if x != nil {
+		return x.Trades
+	}
+	return 0
+}
+
+// ParsedBlock - payload of parser.sys.parsed
+type ParsedBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DirectSwaps     []*DirectSwap     `protobuf:"bytes,1,rep,name=direct_swaps,json=directSwaps,proto3" json:"direct_swaps,omitempty"`
+	PairSwaps       []*PairSwap       `protobuf:"bytes,2,rep,name=pair_swaps,json=pairSwaps,proto3" json:"pair_swaps,omitempty"`
+	LiquidityEvents []*LiquidityEvent `protobuf:"bytes,3,rep,name=liquidity_events,json=liquidityEvents,proto3" json:"liquidity_events,omitempty"`
+	TransferEvents  []*TransferEvent  `protobuf:"bytes,4,rep,name=transfer_events,json=transferEvents,proto3" json:"transfer_events,omitempty"`
+	NewPairs        []*NewPair        `protobuf:"bytes,5,rep,name=new_pairs,json=newPairs,proto3" json:"new_pairs,omitempty"`
+	Holders         []*Holder         `protobuf:"bytes,6,rep,name=holders,proto3" json:"holders,omitempty"`
+	Block           *Block            `protobuf:"bytes,7,opt,name=block,proto3" json:"block,omitempty"`
+	Prices          []*TokenPrice     `protobuf:"bytes,8,rep,name=prices,proto3" json:"prices,omitempty"`
+}
+
+func (x *ParsedBlock) Reset() {
+	*x = ParsedBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_parser_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParsedBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParsedBlock) ProtoMessage() {}
+
+func (x *ParsedBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_parser_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParsedBlock.ProtoReflect.Descriptor instead.
+func (*ParsedBlock) Descriptor() ([]byte, []int) {
+	return file_parser_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ParsedBlock) GetDirectSwaps() []*DirectSwap {
+	if x != nil {
+		return x.DirectSwaps
+	}
+	return nil
+}
+
+func (x *ParsedBlock) GetPairSwaps() []*PairSwap {
+	if x != nil {
+		return x.PairSwaps
+	}
+	return nil
+}
+
+func (x *ParsedBlock) GetLiquidityEvents() []*LiquidityEvent {
+	if x != nil {
+		return x.LiquidityEvents
+	}
+	return nil
+}
+
+func (x *ParsedBlock) GetTransferEvents() []*TransferEvent {
+	if x != nil {
+		return x.TransferEvents
+	}
+	return nil
+}
+
+func (x *ParsedBlock) GetNewPairs() []*NewPair {
+	if x != nil {
+		return x.NewPairs
+	}
+	return nil
+}
+
+func (x *ParsedBlock) GetHolders() []*Holder {
+	if x != nil {
+		return x.Holders
+	}
+	return nil
+}
+
+func (x *ParsedBlock) GetBlock() *Block {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+func (x *ParsedBlock) GetPrices() []*TokenPrice {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+// HoldersBlock - payload of holders topic
+type HoldersBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Block     uint64    `protobuf:"varint,1,opt,name=block,proto3" json:"block,omitempty"`
+	Timestamp int64     `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Chain     string    `protobuf:"bytes,3,opt,name=chain,proto3" json:"chain,omitempty"`
+	Holders   []*Holder `protobuf:"bytes,4,rep,name=holders,proto3" json:"holders,omitempty"`
+	Notify    bool      `protobuf:"varint,5,opt,name=notify,proto3" json:"notify,omitempty"`
+}
+
+func (x *HoldersBlock) Reset() {
+	*x = HoldersBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_parser_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HoldersBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HoldersBlock) ProtoMessage() {}
+
+func (x *HoldersBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_parser_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HoldersBlock.ProtoReflect.Descriptor instead.
+func (*HoldersBlock) Descriptor() ([]byte, []int) {
+	return file_parser_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *HoldersBlock) GetBlock() uint64 {
+	if x != nil {
+		return x.Block
+	}
+	return 0
+}
+
+func (x *HoldersBlock) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *HoldersBlock) GetChain() string {
+	if x != nil {
+		return x.Chain
+	}
+	return ""
+}
+
+func (x *HoldersBlock) GetHolders() []*Holder {
+	if x != nil {
+		return x.Holders
+	}
+	return nil
+}
+
+func (x *HoldersBlock) GetNotify() bool {
+	if x != nil {
+		return x.Notify
+	}
+	return false
+}
+
+// EntityEvent - single event of fan-out topic with the block it belongs to
+type EntityEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Block *Block `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	// Types that are assignable to Event:
+	//	*EntityEvent_PairSwap
+	//	*EntityEvent_DirectSwap
+	//	*EntityEvent_LiquidityEvent
+	//	*EntityEvent_NewPair
+	//	*EntityEvent_TransferEvent
+	Event isEntityEvent_Event `protobuf_oneof:"event"`
+}
+
+func (x *EntityEvent) Reset() {
+	*x = EntityEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_parser_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EntityEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityEvent) ProtoMessage() {}
+
+func (x *EntityEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_parser_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityEvent.ProtoReflect.Descriptor instead.
+func (*EntityEvent) Descriptor() ([]byte, []int) {
+	return file_parser_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *EntityEvent) GetBlock() *Block {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+func (m *EntityEvent) GetEvent() isEntityEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *EntityEvent) GetPairSwap() *PairSwap {
+	if x, ok := x.GetEvent().(*EntityEvent_PairSwap); ok {
+		return x.PairSwap
+	}
+	return nil
+}
+
+func (x *EntityEvent) GetDirectSwap() *DirectSwap {
+	if x, ok := x.GetEvent().(*EntityEvent_DirectSwap); ok {
+		return x.DirectSwap
+	}
+	return nil
+}
+
+func (x *EntityEvent) GetLiquidityEvent() *LiquidityEvent {
+	if x, ok := x.GetEvent().(*EntityEvent_LiquidityEvent); ok {
+		return x.LiquidityEvent
+	}
+	return nil
+}
+
+func (x *EntityEvent) GetNewPair() *NewPair {
+	if x, ok := x.GetEvent().(*EntityEvent_NewPair); ok {
+		return x.NewPair
+	}
+	return nil
+}
+
+func (x *EntityEvent) GetTransferEvent() *TransferEvent {
+	if x, ok := x.GetEvent().(*EntityEvent_TransferEvent); ok {
+		return x.TransferEvent
+	}
+	return nil
+}
+
+type isEntityEvent_Event interface {
+	isEntityEvent_Event()
+}
+
+type EntityEvent_PairSwap struct {
+	PairSwap *PairSwap `protobuf:"bytes,2,opt,name=pair_swap,json=pairSwap,proto3,oneof"`
+}
+
+type EntityEvent_DirectSwap struct {
+	DirectSwap *DirectSwap `protobuf:"bytes,3,opt,name=direct_swap,json=directSwap,proto3,oneof"`
+}
+
+type EntityEvent_LiquidityEvent struct {
+	LiquidityEvent *LiquidityEvent `protobuf:"bytes,4,opt,name=liquidity_event,json=liquidityEvent,proto3,oneof"`
+}
+
+type EntityEvent_NewPair struct {
+	NewPair *NewPair `protobuf:"bytes,5,opt,name=new_pair,json=newPair,proto3,oneof"`
+}
+
+type EntityEvent_TransferEvent struct {
+	TransferEvent *TransferEvent `protobuf:"bytes,6,opt,name=transfer_event,json=transferEvent,proto3,oneof"`
+}
+
+func (*EntityEvent_PairSwap) isEntityEvent_Event() {}
+
+func (*EntityEvent_DirectSwap) isEntityEvent_Event() {}
+
+func (*EntityEvent_LiquidityEvent) isEntityEvent_Event() {}
+
+func (*EntityEvent_NewPair) isEntityEvent_Event() {}
+
+func (*EntityEvent_TransferEvent) isEntityEvent_Event() {}
+
+// EntityBatch - all events of one entity of block (KAFKA_FAN_OUT_BATCH=true), only the list of topic's entity is set
+type EntityBatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Block           *Block            `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	PairSwaps       []*PairSwap       `protobuf:"bytes,2,rep,name=pair_swaps,json=pairSwaps,proto3" json:"pair_swaps,omitempty"`
+	DirectSwaps     []*DirectSwap     `protobuf:"bytes,3,rep,name=direct_swaps,json=directSwaps,proto3" json:"direct_swaps,omitempty"`
+	LiquidityEvents []*LiquidityEvent `protobuf:"bytes,4,rep,name=liquidity_events,json=liquidityEvents,proto3" json:"liquidity_events,omitempty"`
+	NewPairs        []*NewPair        `protobuf:"bytes,5,rep,name=new_pairs,json=newPairs,proto3" json:"new_pairs,omitempty"`
+	TransferEvents  []*TransferEvent  `protobuf:"bytes,6,rep,name=transfer_events,json=transferEvents,proto3" json:"transfer_events,omitempty"`
+}
+
+func (x *EntityBatch) Reset() {
+	*x = EntityBatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_parser_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EntityBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityBatch) ProtoMessage() {}
+
+func (x *EntityBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_parser_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityBatch.ProtoReflect.Descriptor instead.
+func (*EntityBatch) Descriptor() ([]byte, []int) {
+	return file_parser_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *EntityBatch) GetBlock() *Block {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+func (x *EntityBatch) GetPairSwaps() []*PairSwap {
+	if x != nil {
+		return x.PairSwaps
+	}
+	return nil
+}
+
+func (x *EntityBatch) GetDirectSwaps() []*DirectSwap {
+	if x != nil {
+		return x.DirectSwaps
+	}
+	return nil
+}
+
+func (x *EntityBatch) GetLiquidityEvents() []*LiquidityEvent {
+	if x != nil {
+		return x.LiquidityEvents
+	}
+	return nil
+}
+
+func (x *EntityBatch) GetNewPairs() []*NewPair {
+	if x != nil {
+		return x.NewPairs
+	}
+	return nil
+}
+
+func (x *EntityBatch) GetTransferEvents() []*TransferEvent {
+	if x != nil {
+		return x.TransferEvents
+	}
+	return nil
+}
+
+var File_parser_proto protoreflect.FileDescriptor
+
+var file_parser_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x15,
+	0x74, 0x72, 0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x73,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x83, 0x01, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x22, 0x73, 0x0a, 0x0a,
+	0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x35, 0x0a, 0x06, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x74, 0x72, 0x6f,
+	0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x22, 0x9c, 0x05, 0x0a, 0x08, 0x50, 0x61, 0x69, 0x72, 0x53, 0x77, 0x61, 0x70, 0x12, 0x0e,
+	0x0a, 0x02, 0x74, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x78, 0x12, 0x2e,
+	0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x30, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x30, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x31,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x31, 0x12,
+	0x10, 0x0a, 0x03, 0x62, 0x75, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x62, 0x75,
+	0x79, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x61, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x69, 0x63, 0x65, 0x41, 0x12, 0x1e, 0x0a, 0x0b, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x5f, 0x61, 0x5f, 0x75, 0x73, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x70, 0x72, 0x69, 0x63, 0x65, 0x41, 0x55, 0x73, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x5f, 0x62, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x42, 0x12, 0x1e, 0x0a, 0x0b, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x62, 0x5f, 0x75,
+	0x73, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x69, 0x63, 0x65, 0x42,
+	0x55, 0x73, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x6f, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x03, 0x62, 0x6f, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x75, 0x73, 0x64,
+	0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x55, 0x73, 0x64,
+	0x12, 0x4f, 0x0a, 0x12, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x61, 0x5f, 0x70, 0x72, 0x6f, 0x76,
+	0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x74,
+	0x72, 0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x73, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52,
+	0x10, 0x70, 0x72, 0x69, 0x63, 0x65, 0x41, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63,
+	0x65, 0x12, 0x4f, 0x0a, 0x12, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x62, 0x5f, 0x70, 0x72, 0x6f,
+	0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e,
+	0x74, 0x72, 0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x73,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65,
+	0x52, 0x10, 0x70, 0x72, 0x69, 0x63, 0x65, 0x42, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e,
+	0x63, 0x65, 0x12, 0x4c, 0x0a, 0x10, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x76,
+	0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x74,
+	0x72, 0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x73, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52,
+	0x0f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65,
+	0x22, 0xbc, 0x05, 0x0a, 0x0a, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x53, 0x77, 0x61, 0x70, 0x12,
+	0x0e, 0x0a, 0x02, 0x74, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x78, 0x12,
+	0x2e, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x72, 0x63, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x72, 0x63, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x73, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x73, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x30, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x30, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x31, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x31, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x61, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x69, 0x63, 0x65, 0x41, 0x12, 0x1e, 0x0a, 0x0b, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x5f, 0x61, 0x5f, 0x75, 0x73, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x70, 0x72, 0x69, 0x63, 0x65, 0x41, 0x55, 0x73, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x5f, 0x62, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x42, 0x12, 0x1e, 0x0a, 0x0b, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x62, 0x5f,
+	0x75, 0x73, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x42, 0x55, 0x73, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x75, 0x73, 0x64, 0x18,
+	0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x55, 0x73, 0x64, 0x12,
+	0x4f, 0x0a, 0x12, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x61, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x65,
+	0x6e, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x74, 0x72,
+	0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x10,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x41, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65,
+	0x12, 0x4f, 0x0a, 0x12, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x62, 0x5f, 0x70, 0x72, 0x6f, 0x76,
+	0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x74,
+	0x72, 0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x73, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52,
+	0x10, 0x70, 0x72, 0x69, 0x63, 0x65, 0x42, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63,
+	0x65, 0x12, 0x4c, 0x0a, 0x10, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x65,
+	0x6e, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x74, 0x72,
+	0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x0f,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x22,
+	0x9c, 0x05, 0x0a, 0x0e, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2e, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x74, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x12,
+	0x14, 0x0a, 0x05, 0x6b, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x6b, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x30, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x30, 0x12,
+	0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x31, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x31, 0x12, 0x17, 0x0a, 0x07, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x5f, 0x61, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x41, 0x12, 0x1e, 0x0a, 0x0b, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x61, 0x5f,
+	0x75, 0x73, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x41, 0x55, 0x73, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x62, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x69, 0x63, 0x65, 0x42, 0x12, 0x1e, 0x0a,
+	0x0b, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x62, 0x5f, 0x75, 0x73, 0x64, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x69, 0x63, 0x65, 0x42, 0x55, 0x73, 0x64, 0x12, 0x1f, 0x0a,
+	0x0b, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x5f, 0x75, 0x73, 0x64, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x55, 0x73, 0x64, 0x12, 0x4f,
+	0x0a, 0x12, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x61, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6e,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x74, 0x72, 0x6f,
+	0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x10, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x41, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12,
+	0x4f, 0x0a, 0x12, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x62, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x65,
+	0x6e, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x74, 0x72,
+	0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x10,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x42, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65,
+	0x12, 0x4c, 0x0a, 0x10, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6e,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x74, 0x72, 0x6f,
+	0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x0f, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x0f,
+	0x0a, 0x0d, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22,
+	0x9e, 0x01, 0x0a, 0x07, 0x4e, 0x65, 0x77, 0x50, 0x61, 0x69, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x66,
+	0x61, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x66, 0x61,
+	0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6b, 0x6c, 0x61,
+	0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6b, 0x6c, 0x61, 0x73, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x64,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x21, 0x0a,
+	0x0c, 0x70, 0x6f, 0x6f, 0x6c, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0b, 0x70, 0x6f, 0x6f, 0x6c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x22, 0x52, 0x0a, 0x06, 0x48, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x74, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x74, 0x78, 0x22, 0xd5, 0x01, 0x0a, 0x0a, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x50, 0x72,
+	0x69, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6f, 0x70, 0x65,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6f, 0x70, 0x65, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x68, 0x69, 0x67, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x69, 0x67,
+	0x68, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x6f, 0x77, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6c, 0x6f, 0x77, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x76, 0x77, 0x61,
+	0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x76, 0x77, 0x61, 0x70, 0x12, 0x16, 0x0a,
+	0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76,
+	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f,
+	0x75, 0x73, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x76, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x55, 0x73, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x72, 0x61, 0x64, 0x65, 0x73, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x74, 0x72, 0x61, 0x64, 0x65, 0x73, 0x22, 0x99, 0x04, 0x0a,
+	0x0b, 0x50, 0x61, 0x72, 0x73, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x44, 0x0a, 0x0c,
+	0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x74, 0x72, 0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73,
+	0x5f, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x53, 0x77, 0x61, 0x70, 0x52, 0x0b, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x53, 0x77, 0x61,
+	0x70, 0x73, 0x12, 0x3e, 0x0a, 0x0a, 0x70, 0x61, 0x69, 0x72, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x74, 0x72, 0x6f, 0x6e, 0x5f, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x61, 0x69, 0x72, 0x53, 0x77, 0x61, 0x70, 0x52, 0x09, 0x70, 0x61, 0x69, 0x72, 0x53, 0x77, 0x61,
+	0x70, 0x73, 0x12, 0x50, 0x0a, 0x10, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x5f,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x74,
+	0x72, 0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x73, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x52, 0x0f, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x73, 0x12, 0x4d, 0x0a, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e,
+	0x74, 0x72, 0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x73,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x52, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x12, 0x3b, 0x0a, 0x09, 0x6e, 0x65, 0x77, 0x5f, 0x70, 0x61, 0x69, 0x72, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x74, 0x72, 0x6f, 0x6e, 0x5f, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4e,
+	0x65, 0x77, 0x50, 0x61, 0x69, 0x72, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x50, 0x61, 0x69, 0x72, 0x73,
+	0x12, 0x37, 0x0a, 0x07, 0x68, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x72, 0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x5f,
+	0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x6c, 0x64, 0x65, 0x72,
+	0x52, 0x07, 0x68, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x73, 0x12, 0x32, 0x0a, 0x05, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x74, 0x72, 0x6f, 0x6e, 0x5f,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x39, 0x0a,
+	0x06, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e,
+	0x74, 0x72, 0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x73,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x52, 0x06, 0x70, 0x72, 0x69, 0x63, 0x65, 0x73, 0x22, 0xa9, 0x01, 0x0a, 0x0c, 0x48, 0x6f, 0x6c,
+	0x64, 0x65, 0x72, 0x73, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12,
+	0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x12, 0x37, 0x0a, 0x07, 0x68, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x72, 0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x6c,
+	0x64, 0x65, 0x72, 0x52, 0x07, 0x68, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6e, 0x6f,
+	0x74, 0x69, 0x66, 0x79, 0x22, 0xae, 0x03, 0x0a, 0x0b, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x12, 0x32, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x74, 0x72, 0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x73, 0x5f, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x52, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x3e, 0x0a, 0x09, 0x70, 0x61, 0x69, 0x72,
+	0x5f, 0x73, 0x77, 0x61, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x74, 0x72,
+	0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x69, 0x72, 0x53, 0x77, 0x61, 0x70, 0x48, 0x00, 0x52, 0x08,
+	0x70, 0x61, 0x69, 0x72, 0x53, 0x77, 0x61, 0x70, 0x12, 0x44, 0x0a, 0x0b, 0x64, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e,
+	0x74, 0x72, 0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x73,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x53, 0x77, 0x61, 0x70,
+	0x48, 0x00, 0x52, 0x0a, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x53, 0x77, 0x61, 0x70, 0x12, 0x50,
+	0x0a, 0x0f, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x5f, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x74, 0x72, 0x6f, 0x6e, 0x5f, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00,
+	0x52, 0x0e, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x12, 0x3b, 0x0a, 0x08, 0x6e, 0x65, 0x77, 0x5f, 0x70, 0x61, 0x69, 0x72, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x74, 0x72, 0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73,
+	0x5f, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x65, 0x77, 0x50, 0x61,
+	0x69, 0x72, 0x48, 0x00, 0x52, 0x07, 0x6e, 0x65, 0x77, 0x50, 0x61, 0x69, 0x72, 0x12, 0x4d, 0x0a,
+	0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x74, 0x72, 0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x42, 0x07, 0x0a, 0x05,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0xa5, 0x03, 0x0a, 0x0b, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x32, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x74, 0x72, 0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x52, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x3e, 0x0a, 0x0a, 0x70, 0x61, 0x69,
+	0x72, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x74, 0x72, 0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x73,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x69, 0x72, 0x53, 0x77, 0x61, 0x70, 0x52, 0x09,
+	0x70, 0x61, 0x69, 0x72, 0x53, 0x77, 0x61, 0x70, 0x73, 0x12, 0x44, 0x0a, 0x0c, 0x64, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x21, 0x2e, 0x74, 0x72, 0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x70, 0x61,
+	0x72, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x53, 0x77,
+	0x61, 0x70, 0x52, 0x0b, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x53, 0x77, 0x61, 0x70, 0x73, 0x12,
+	0x50, 0x0a, 0x10, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x5f, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x74, 0x72, 0x6f, 0x6e,
+	0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x4c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x52, 0x0f, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x12, 0x3b, 0x0a, 0x09, 0x6e, 0x65, 0x77, 0x5f, 0x70, 0x61, 0x69, 0x72, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x74, 0x72, 0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x65, 0x77,
+	0x50, 0x61, 0x69, 0x72, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x50, 0x61, 0x69, 0x72, 0x73, 0x12, 0x4d,
+	0x0a, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x74, 0x72, 0x6f, 0x6e, 0x5f, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x0e, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2a, 0x7b, 0x0a,
+	0x06, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x4f, 0x55, 0x52, 0x43,
+	0x45, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x4f, 0x55, 0x52,
+	0x43, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x53,
+	0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x10, 0x02, 0x12, 0x13, 0x0a,
+	0x0f, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x50, 0x52, 0x45, 0x56, 0x49, 0x4f, 0x55, 0x53,
+	0x10, 0x03, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x43, 0x48, 0x45,
+	0x43, 0x4b, 0x50, 0x4f, 0x49, 0x4e, 0x54, 0x10, 0x04, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x4f, 0x55,
+	0x52, 0x43, 0x45, 0x5f, 0x4c, 0x49, 0x56, 0x45, 0x10, 0x05, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6b, 0x61, 0x74, 0x74, 0x61, 0x6e, 0x61,
+	0x2d, 0x69, 0x6f, 0x2f, 0x74, 0x72, 0x6f, 0x6e, 0x2d, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2d,
+	0x70, 0x61, 0x72, 0x73, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_parser_proto_rawDescOnce sync.Once
+	file_parser_proto_rawDescData = file_parser_proto_rawDesc
+)
+
+func file_parser_proto_rawDescGZIP() []byte {
+	file_parser_proto_rawDescOnce.Do(func() {
+		file_parser_proto_rawDescData = protoimpl.X.CompressGZIP(file_parser_proto_rawDescData)
+	})
+	return file_parser_proto_rawDescData
+}
+
+var file_parser_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_parser_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_parser_proto_goTypes = []interface{}{
+	(Source)(0),                   // 0: tron_blocks_parser.v1.Source
+	(*Block)(nil),                 // 1: tron_blocks_parser.v1.Block
+	(*Provenance)(nil),            // 2: tron_blocks_parser.v1.Provenance
+	(*PairSwap)(nil),              // 3: tron_blocks_parser.v1.PairSwap
+	(*DirectSwap)(nil),            // 4: tron_blocks_parser.v1.DirectSwap
+	(*LiquidityEvent)(nil),        // 5: tron_blocks_parser.v1.LiquidityEvent
+	(*TransferEvent)(nil),         // 6: tron_blocks_parser.v1.TransferEvent
+	(*NewPair)(nil),               // 7: tron_blocks_parser.v1.NewPair
+	(*Holder)(nil),                // 8: tron_blocks_parser.v1.Holder
+	(*TokenPrice)(nil),            // 9: tron_blocks_parser.v1.TokenPrice
+	(*ParsedBlock)(nil),           // 10: tron_blocks_parser.v1.ParsedBlock
+	(*HoldersBlock)(nil),          // 11: tron_blocks_parser.v1.HoldersBlock
+	(*EntityEvent)(nil),           // 12: tron_blocks_parser.v1.EntityEvent
+	(*EntityBatch)(nil),           // 13: tron_blocks_parser.v1.EntityBatch
+	(*timestamppb.Timestamp)(nil), // 14: google.protobuf.Timestamp
+}
+var file_parser_proto_depIdxs = []int32{
+	0,  // 0: tron_blocks_parser.v1.Provenance.source:type_name -> tron_blocks_parser.v1.Source
+	14, // 1: tron_blocks_parser.v1.PairSwap.date:type_name -> google.protobuf.Timestamp
+	2,  // 2: tron_blocks_parser.v1.PairSwap.price_a_provenance:type_name -> tron_blocks_parser.v1.Provenance
+	2,  // 3: tron_blocks_parser.v1.PairSwap.price_b_provenance:type_name -> tron_blocks_parser.v1.Provenance
+	2,  // 4: tron_blocks_parser.v1.PairSwap.value_provenance:type_name -> tron_blocks_parser.v1.Provenance
+	14, // 5: tron_blocks_parser.v1.DirectSwap.date:type_name -> google.protobuf.Timestamp
+	2,  // 6: tron_blocks_parser.v1.DirectSwap.price_a_provenance:type_name -> tron_blocks_parser.v1.Provenance
+	2,  // 7: tron_blocks_parser.v1.DirectSwap.price_b_provenance:type_name -> tron_blocks_parser.v1.Provenance
+	2,  // 8: tron_blocks_parser.v1.DirectSwap.value_provenance:type_name -> tron_blocks_parser.v1.Provenance
+	14, // 9: tron_blocks_parser.v1.LiquidityEvent.date:type_name -> google.protobuf.Timestamp
+	2,  // 10: tron_blocks_parser.v1.LiquidityEvent.price_a_provenance:type_name -> tron_blocks_parser.v1.Provenance
+	2,  // 11: tron_blocks_parser.v1.LiquidityEvent.price_b_provenance:type_name -> tron_blocks_parser.v1.Provenance
+	2,  // 12: tron_blocks_parser.v1.LiquidityEvent.value_provenance:type_name -> tron_blocks_parser.v1.Provenance
+	4,  // 13: tron_blocks_parser.v1.ParsedBlock.direct_swaps:type_name -> tron_blocks_parser.v1.DirectSwap
+	3,  // 14: tron_blocks_parser.v1.ParsedBlock.pair_swaps:type_name -> tron_blocks_parser.v1.PairSwap
+	5,  // 15: tron_blocks_parser.v1.ParsedBlock.liquidity_events:type_name -> tron_blocks_parser.v1.LiquidityEvent
+	6,  // 16: tron_blocks_parser.v1.ParsedBlock.transfer_events:type_name -> tron_blocks_parser.v1.TransferEvent
+	7,  // 17: tron_blocks_parser.v1.ParsedBlock.new_pairs:type_name -> tron_blocks_parser.v1.NewPair
+	8,  // 18: tron_blocks_parser.v1.ParsedBlock.holders:type_name -> tron_blocks_parser.v1.Holder
+	1,  // 19: tron_blocks_parser.v1.ParsedBlock.block:type_name -> tron_blocks_parser.v1.Block
+	9,  // 20: tron_blocks_parser.v1.ParsedBlock.prices:type_name -> tron_blocks_parser.v1.TokenPrice
+	8,  // 21: tron_blocks_parser.v1.HoldersBlock.holders:type_name -> tron_blocks_parser.v1.Holder
+	1,  // 22: tron_blocks_parser.v1.EntityEvent.block:type_name -> tron_blocks_parser.v1.Block
+	3,  // 23: tron_blocks_parser.v1.EntityEvent.pair_swap:type_name -> tron_blocks_parser.v1.PairSwap
+	4,  // 24: tron_blocks_parser.v1.EntityEvent.direct_swap:type_name -> tron_blocks_parser.v1.DirectSwap
+	5,  // 25: tron_blocks_parser.v1.EntityEvent.liquidity_event:type_name -> tron_blocks_parser.v1.LiquidityEvent
+	7,  // 26: tron_blocks_parser.v1.EntityEvent.new_pair:type_name -> tron_blocks_parser.v1.NewPair
+	6,  // 27: tron_blocks_parser.v1.EntityEvent.transfer_event:type_name -> tron_blocks_parser.v1.TransferEvent
+	1,  // 28: tron_blocks_parser.v1.EntityBatch.block:type_name -> tron_blocks_parser.v1.Block
+	3,  // 29: tron_blocks_parser.v1.EntityBatch.pair_swaps:type_name -> tron_blocks_parser.v1.PairSwap
+	4,  // 30: tron_blocks_parser.v1.EntityBatch.direct_swaps:type_name -> tron_blocks_parser.v1.DirectSwap
+	5,  // 31: tron_blocks_parser.v1.EntityBatch.liquidity_events:type_name -> tron_blocks_parser.v1.LiquidityEvent
+	7,  // 32: tron_blocks_parser.v1.EntityBatch.new_pairs:type_name -> tron_blocks_parser.v1.NewPair
+	6,  // 33: tron_blocks_parser.v1.EntityBatch.transfer_events:type_name -> tron_blocks_parser.v1.TransferEvent
+	34, // [34:34] is the sub-list for method output_type
+	34, // [34:34] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
+}
+
+func init() { file_parser_proto_init() }
+func file_parser_proto_init() {
+	if File_parser_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_parser_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Block); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_parser_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Provenance); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_parser_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PairSwap); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_parser_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DirectSwap); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_parser_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LiquidityEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_parser_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransferEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_parser_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewPair); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_parser_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Holder); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_parser_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TokenPrice); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_parser_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ParsedBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_parser_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HoldersBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_parser_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EntityEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_parser_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EntityBatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_parser_proto_msgTypes[11].OneofWrappers = []interface{}{
+		(*EntityEvent_PairSwap)(nil),
+		(*EntityEvent_DirectSwap)(nil),
+		(*EntityEvent_LiquidityEvent)(nil),
+		(*EntityEvent_NewPair)(nil),
+		(*EntityEvent_TransferEvent)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_parser_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_parser_proto_goTypes,
+		DependencyIndexes: file_parser_proto_depIdxs,
+		EnumInfos:         file_parser_proto_enumTypes,
+		MessageInfos:      file_parser_proto_msgTypes,
+	}.Build()
+	File_parser_proto = out.File
+	file_parser_proto_rawDesc = nil
+	file_parser_proto_goTypes = nil
+	file_parser_proto_depIdxs = nil
+}
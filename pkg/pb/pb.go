@@ -0,0 +1,9 @@
+/*
+Package pb contains messages of parser payloads published with protobuf encoding, generated from parser.proto.
+
+Message of a topic is defined by its contents: ParsedBlock for parsed blocks, HoldersBlock for holders,
+EntityEvent or EntityBatch for fan-out topics. Decimal values are strings.
+*/
+package pb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative parser.proto